@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var filterOperators = map[string]string{
+	"":                   "=",
+	"equal":              "=",
+	"notEqual":           "!=",
+	"greaterThan":        ">",
+	"greaterThanOrEqual": ">=",
+	"lessThan":           "<",
+	"lessThanOrEqual":    "<=",
+}
+
+type autoFilter struct {
+	Ref     string `xml:"ref,attr"`
+	Columns []struct {
+		ColID   int `xml:"colId,attr"`
+		Filters *struct {
+			Blank  bool `xml:"blank,attr"`
+			Values []struct {
+				Val string `xml:"val,attr"`
+			} `xml:"filter"`
+		} `xml:"filters"`
+		CustomFilters *struct {
+			And     bool `xml:"and,attr"`
+			Filters []struct {
+				Operator string `xml:"operator,attr"`
+				Val      string `xml:"val,attr"`
+			} `xml:"customFilter"`
+		} `xml:"customFilters"`
+		DynamicFilter *struct {
+			Type string `xml:"type,attr"`
+		} `xml:"dynamicFilter"`
+		Top10 *struct {
+			Top     *bool  `xml:"top,attr"`
+			Percent bool   `xml:"percent,attr"`
+			Val     string `xml:"val,attr"`
+		} `xml:"top10"`
+		ColorFilter *struct{} `xml:"colorFilter"`
+		IconFilter  *struct{} `xml:"iconFilter"`
+	} `xml:"filterColumn"`
+}
+
+type filterCriteria struct {
+	column    string
+	condition string
+}
+
+func (f *autoFilter) criteria() []filterCriteria {
+	startCol := 1
+	if parts := strings.Split(f.Ref, ":"); len(parts) > 0 {
+		if col, _, err := excelize.CellNameToCoordinates(parts[0]); err == nil {
+			startCol = col
+		}
+	}
+	result := []filterCriteria{}
+	for _, column := range f.Columns {
+		conditions := []string{}
+		if column.Filters != nil {
+			values := []string{}
+			for _, value := range column.Filters.Values {
+				values = append(values, value.Val)
+			}
+			if column.Filters.Blank {
+				values = append(values, "(空白)")
+			}
+			conditions = append(conditions, "= "+strings.Join(values, "|"))
+		}
+		if column.CustomFilters != nil {
+			parts := []string{}
+			for _, custom := range column.CustomFilters.Filters {
+				operator, ok := filterOperators[custom.Operator]
+				if !ok {
+					operator = custom.Operator
+				}
+				parts = append(parts, operator+" "+custom.Val)
+			}
+			joiner := " 或 "
+			if column.CustomFilters.And {
+				joiner = " 且 "
+			}
+			conditions = append(conditions, strings.Join(parts, joiner))
+		}
+		if column.DynamicFilter != nil {
+			conditions = append(conditions, "动态:"+column.DynamicFilter.Type)
+		}
+		if column.Top10 != nil {
+			label := "前"
+			if column.Top10.Top != nil && !*column.Top10.Top {
+				label = "后"
+			}
+			count := column.Top10.Val
+			if column.Top10.Percent {
+				count += "%"
+			}
+			conditions = append(conditions, fmt.Sprintf("%s%s项", label, count))
+		}
+		if column.ColorFilter != nil {
+			conditions = append(conditions, "按颜色")
+		}
+		if column.IconFilter != nil {
+			conditions = append(conditions, "按图标")
+		}
+		if len(conditions) == 0 {
+			continue
+		}
+		result = append(result, filterCriteria{
+			column:    numberToColumn(startCol + column.ColID),
+			condition: strings.Join(conditions, "; "),
+		})
+	}
+	return result
+}
+
+func printAutoFilter(filter *autoFilter) {
+	if filter == nil || filter.Ref == "" {
+		return
+	}
+	printLine("AutoFilter:%s", strings.ReplaceAll(filter.Ref, "$", ""))
+	for _, item := range filter.criteria() {
+		printLine("Filter:%s %s", item.column, item.condition)
+	}
+}
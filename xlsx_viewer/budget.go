@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+type outputLimits struct {
+	maxCellChars int
+	maxBytes     int
+	maxTokens    int
+	offset       int
+	paged        bool
+	usedBytes    int
+	usedTokens   int
+	truncated    bool
+	exceeded     string
+	reported     bool
+}
+
+var limits outputLimits
+
+func configureOutput(opts options) {
+	limits = outputLimits{
+		maxCellChars: opts.maxCellChars,
+		maxBytes:     opts.maxOutputBytes,
+		maxTokens:    opts.maxOutputTokens,
+		offset:       opts.offset,
+		paged:        opts.op == opRows || opts.op == opCols || opts.op == opSearchCol || opts.op == opSearchRow || opts.op == opTable,
+	}
+}
+
+func (l *outputLimits) allow(line string) bool {
+	if l.truncated {
+		return false
+	}
+	bytes := len(line) + 1
+	tokens := estimateTokens(line) + 1
+	switch {
+	case l.maxBytes > 0 && l.usedBytes+bytes > l.maxBytes:
+		l.exceeded = fmt.Sprintf("--max-output-bytes %d", l.maxBytes)
+	case l.maxTokens > 0 && l.usedTokens+tokens > l.maxTokens:
+		l.exceeded = fmt.Sprintf("--max-output-tokens %d", l.maxTokens)
+	}
+	if l.exceeded != "" {
+		l.truncated = true
+		return false
+	}
+	l.usedBytes += bytes
+	l.usedTokens += tokens
+	return true
+}
+
+// printLine prints a summary or header line within the same budget as the
+// CSV rows, so nothing but the truncation footer follows once it is spent.
+func printLine(format string, args ...any) bool {
+	line := fmt.Sprintf(format, args...)
+	if !limits.allow(line) {
+		return false
+	}
+	fmt.Println(line)
+	return true
+}
+
+// estimateTokens approximates LLM tokenizer cost: roughly four ASCII
+// characters per token, and one token per CJK or other non-ASCII character.
+func estimateTokens(value string) int {
+	ascii, other := 0, 0
+	for _, r := range value {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+func truncateCell(value string) string {
+	if limits.maxCellChars <= 0 {
+		return value
+	}
+	length := utf8.RuneCountInString(value)
+	if length <= limits.maxCellChars {
+		return value
+	}
+	runes := []rune(value)
+	return fmt.Sprintf("%s…(共%d字)", string(runes[:limits.maxCellChars]), length)
+}
+
+func truncateCells(values []string) []string {
+	if limits.maxCellChars <= 0 {
+		return values
+	}
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = truncateCell(value)
+	}
+	return result
+}
+
+// printRowsTruncated only suggests --offset for the operations that page with
+// it; the others can only be narrowed or given a larger budget.
+func printRowsTruncated(shown, total int) {
+	if !limits.report() {
+		return
+	}
+	if !limits.paged {
+		fmt.Printf("输出已截断: 超过 %s 限制, 已显示 %d/%d 行, 可放宽限制或缩小读取范围\n", limits.exceeded, shown, total)
+		return
+	}
+	fmt.Printf("输出已截断: 超过 %s 限制, 已显示 %d/%d 行, 使用 --offset %d 继续\n", limits.exceeded, shown, total, limits.offset+shown)
+}
+
+func printColumnsTruncated(shown, total int) {
+	if !limits.report() {
+		return
+	}
+	fmt.Printf("输出已截断: 超过 %s 限制, 已显示 %d/%d 行, 可减小 --max-rows 或减少列数\n", limits.exceeded, shown, total)
+}
+
+func printGroupsTruncated(shown, total int) {
+	if !limits.report() {
+		return
+	}
+	fmt.Printf("输出已截断: 超过 %s 限制, 已显示 %d/%d 组, 可放宽限制或减小 --limit\n", limits.exceeded, shown, total)
+}
+
+// printOutputTruncated is the footer for summaries that are not a list of
+// rows, such as --id-gaps reports or headers cut off before any data.
+func printOutputTruncated() {
+	if !limits.report() {
+		return
+	}
+	fmt.Printf("输出已截断: 超过 %s 限制, 可放宽限制或缩小读取范围\n", limits.exceeded)
+}
+
+// report lets only the first truncation footer through, so a report made
+// of several tables does not repeat it.
+func (l *outputLimits) report() bool {
+	if l.reported {
+		return false
+	}
+	l.reported = true
+	return true
+}
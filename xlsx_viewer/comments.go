@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var commentCache = map[string]map[string]excelize.Comment{}
+
+func sheetComments(file *excelize.File, sheet string) (map[string]excelize.Comment, error) {
+	key := file.Path + "!" + sheet
+	if comments, ok := commentCache[key]; ok {
+		return comments, nil
+	}
+	list, err := file.GetComments(sheet)
+	if err != nil {
+		return nil, err
+	}
+	comments := make(map[string]excelize.Comment, len(list))
+	for _, comment := range list {
+		comments[strings.ToUpper(comment.Cell)] = comment
+	}
+	commentCache[key] = comments
+	return comments, nil
+}
+
+// commentText joins the comment runs and drops the "Author:" line Excel
+// prepends to legacy notes, since the author is reported separately.
+func commentText(comment excelize.Comment) string {
+	var b strings.Builder
+	b.WriteString(comment.Text)
+	for _, run := range comment.Paragraph {
+		b.WriteString(run.Text)
+	}
+	text := strings.TrimSpace(b.String())
+	if comment.Author != "" {
+		for _, prefix := range []string{comment.Author + ":", comment.Author + "："} {
+			if strings.HasPrefix(text, prefix) {
+				text = strings.TrimSpace(strings.TrimPrefix(text, prefix))
+				break
+			}
+		}
+	}
+	return text
+}
+
+func commentNote(file *excelize.File, sheet, cell string) (string, error) {
+	comments, err := sheetComments(file, sheet)
+	if err != nil {
+		return "", err
+	}
+	comment, ok := comments[cell]
+	if !ok {
+		return "", nil
+	}
+	text := strings.Join(strings.Fields(commentText(comment)), " ")
+	if comment.Author == "" {
+		return "批注:" + text, nil
+	}
+	return fmt.Sprintf("批注(%s):%s", comment.Author, text), nil
+}
+
+func handleListComments(file *excelize.File, sheet string) {
+	comments, err := sheetComments(file, sheet)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	cells := make([]string, 0, len(comments))
+	for cell := range comments {
+		cells = append(cells, cell)
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		ci, ri, _ := excelize.CellNameToCoordinates(cells[i])
+		cj, rj, _ := excelize.CellNameToCoordinates(cells[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return ci < cj
+	})
+	if !printLine("批注: 共 %d 个", len(cells)) {
+		printOutputTruncated()
+		return
+	}
+	if len(cells) == 0 {
+		return
+	}
+	printCSVRow([]string{"单元格", "作者", "批注", "单元格值"})
+	for i, cell := range cells {
+		comment := comments[cell]
+		col, row, err := excelize.CellNameToCoordinates(cell)
+		if err != nil {
+			exitWithError(err.Error())
+		}
+		value, err := cellValue(file, sheet, row, col)
+		if err != nil {
+			exitWithError(err.Error())
+		}
+		line := []string{cell, comment.Author, truncateCell(commentText(comment)), truncateCell(value)}
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(cells))
+			return
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+var textEncodings = map[string]string{
+	"utf-8":  "utf-8",
+	"utf8":   "utf-8",
+	"gbk":    "gbk",
+	"utf-16": "utf-16",
+	"utf16":  "utf-16",
+}
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+type csvReader struct {
+	delimiter rune
+	encoding  string
+}
+
+func isTextTable(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".csv" || ext == ".tsv"
+}
+
+func newCSVReader(path string, opts options) (csvReader, error) {
+	reader := csvReader{delimiter: ',', encoding: opts.encoding}
+	if strings.ToLower(filepath.Ext(path)) == ".tsv" {
+		reader.delimiter = '\t'
+	}
+	if opts.delimiter != "" {
+		delimiter, err := parseDelimiter(opts.delimiter)
+		if err != nil {
+			return reader, err
+		}
+		reader.delimiter = delimiter
+	}
+	return reader, nil
+}
+
+func parseDelimiter(value string) (rune, error) {
+	switch strings.ToLower(value) {
+	case `\t`, "tab":
+		return '\t', nil
+	}
+	delimiter, size := utf8.DecodeRuneInString(value)
+	if size != len(value) || delimiter == utf8.RuneError || strings.ContainsRune("\"\r\n", delimiter) {
+		return 0, fmt.Errorf("无效的分隔符: %s (需要单个字符, 制表符用 \\t 或 tab)", value)
+	}
+	return delimiter, nil
+}
+
+func (r csvReader) read(path string) (*sourceWorkbook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	text, encoding, err := decodeText(data, r.encoding)
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = r.delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("第 %d 行格式错误: %s", parseErr.Line, parseErr.Err.Error())
+		}
+		return nil, err
+	}
+
+	sheet := sourceSheet{name: textSheetName(path)}
+	for i, record := range records {
+		for j, value := range record {
+			if value != "" {
+				sheet.cells = append(sheet.cells, sourceCell{row: i + 1, col: j + 1, kind: sourceText, text: value})
+			}
+		}
+	}
+	kind := workbookKind{extension: strings.ToLower(filepath.Ext(path))}
+	kind.description = fmt.Sprintf("分隔符 %s, 编码 %s", delimiterName(r.delimiter), encoding)
+	return &sourceWorkbook{kind: kind, sheets: []sourceSheet{sheet}}, nil
+}
+
+// decodeText converts the file to UTF-8. A byte order mark takes precedence
+// over --encoding; without either the file must be valid UTF-8.
+func decodeText(data []byte, encoding string) (string, string, error) {
+	switch {
+	case bytes.HasPrefix(data, utf8BOM):
+		return string(data[len(utf8BOM):]), "UTF-8 (BOM)", nil
+	case bytes.HasPrefix(data, utf16LEBOM), bytes.HasPrefix(data, utf16BEBOM):
+		encoding = "utf-16"
+	}
+	switch encoding {
+	case "gbk":
+		decoded, err := simplifiedchinese.GBK.NewDecoder().Bytes(data)
+		if err != nil {
+			return "", "", fmt.Errorf("无法按 GBK 解码: %s", err.Error())
+		}
+		return string(decoded), "GBK", nil
+	case "utf-16":
+		decoded, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewDecoder().Bytes(data)
+		if err != nil {
+			return "", "", fmt.Errorf("无法按 UTF-16 解码: %s", err.Error())
+		}
+		if bytes.HasPrefix(data, utf16BEBOM) {
+			return string(decoded), "UTF-16BE", nil
+		}
+		return string(decoded), "UTF-16LE", nil
+	}
+	if !utf8.Valid(data) {
+		return "", "", errors.New("不是有效的 UTF-8 文本, 请用 --encoding gbk 或 --encoding utf-16 指定编码")
+	}
+	return string(data), "UTF-8", nil
+}
+
+func delimiterName(delimiter rune) string {
+	if delimiter == '\t' {
+		return "制表符"
+	}
+	return fmt.Sprintf("'%c'", delimiter)
+}
+
+// textSheetName names the single sheet of a text table after the file,
+// within the characters and length Excel allows for sheet names.
+func textSheetName(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, strings.Trim(name, "'"))
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" {
+		return "Sheet1"
+	}
+	return name
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const defaultDateFormat = "iso"
+
+var dateFormatTokens = []struct {
+	token  string
+	layout string
+}{
+	{"yyyy", "2006"},
+	{"yy", "06"},
+	{"MM", "01"},
+	{"dd", "02"},
+	{"HH", "15"},
+	{"mm", "04"},
+	{"ss", "05"},
+}
+
+// dateLayout converts a --date-format pattern such as "yyyy/MM/dd HH:mm" to a
+// Go time layout; "iso" and "excel" are handled by the caller.
+func dateLayout(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); {
+		matched := false
+		for _, t := range dateFormatTokens {
+			if strings.HasPrefix(pattern[i:], t.token) {
+				b.WriteString(t.layout)
+				i += len(t.token)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(pattern[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+func stripFormatLiterals(code string) string {
+	var b strings.Builder
+	inQuote, inBracket := false, false
+	for i := 0; i < len(code); i++ {
+		ch := code[i]
+		switch {
+		case inQuote:
+			inQuote = ch != '"'
+		case inBracket:
+			inBracket = ch != ']'
+		case ch == '"':
+			inQuote = true
+		case ch == '[':
+			inBracket = true
+		case ch == '\\' || ch == '_' || ch == '*':
+			i++
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return strings.ToLower(b.String())
+}
+
+func dateFormatParts(code string) (hasDate, hasTime bool) {
+	if strings.EqualFold(code, "General") {
+		return false, false
+	}
+	section := stripFormatLiterals(strings.SplitN(code, ";", 2)[0])
+	hasTime = strings.ContainsAny(section, "hs")
+	hasDate = strings.ContainsAny(section, "yd") || (strings.Contains(section, "m") && !hasTime)
+	return hasDate, hasTime
+}
+
+type dateParts struct {
+	hasDate bool
+	hasTime bool
+}
+
+type dateStyleKey struct {
+	file  *excelize.File
+	style int
+}
+
+// Every displayed cell goes through cellTime, so the workbook's date system
+// and the date parts of each style are looked up once rather than per cell.
+var (
+	date1904Cache  = map[*excelize.File]bool{}
+	dateStyleCache = map[dateStyleKey]dateParts{}
+)
+
+func isDate1904(file *excelize.File) bool {
+	if value, ok := date1904Cache[file]; ok {
+		return value
+	}
+	props, err := file.GetWorkbookProps()
+	value := err == nil && props.Date1904 != nil && *props.Date1904
+	date1904Cache[file] = value
+	return value
+}
+
+func styleDateParts(file *excelize.File, styleIdx int) dateParts {
+	key := dateStyleKey{file: file, style: styleIdx}
+	if parts, ok := dateStyleCache[key]; ok {
+		return parts
+	}
+	var parts dateParts
+	if code, err := styleFormatCode(file, styleIdx); err == nil {
+		parts.hasDate, parts.hasTime = dateFormatParts(code)
+	}
+	dateStyleCache[key] = parts
+	return parts
+}
+
+// cellTime returns the time for numeric cells carrying a date or time number
+// format, or for cells of the explicit ISO 8601 date type.
+func cellTime(file *excelize.File, sheet, cell string) (time.Time, dateParts, bool) {
+	styleIdx, err := file.GetCellStyle(sheet, cell)
+	if err != nil {
+		return time.Time{}, dateParts{}, false
+	}
+	parts := styleDateParts(file, styleIdx)
+	if parts.hasDate || parts.hasTime {
+		raw, err := file.GetCellValue(sheet, cell, excelize.Options{RawCellValue: true})
+		if err != nil || raw == "" {
+			return time.Time{}, dateParts{}, false
+		}
+		if serial, err := strconv.ParseFloat(raw, 64); err == nil {
+			parsed, err := excelize.ExcelDateToTime(serial, isDate1904(file))
+			return parsed, parts, err == nil
+		}
+	}
+	if cellType, err := file.GetCellType(sheet, cell); err != nil || cellType != excelize.CellTypeDate {
+		return time.Time{}, dateParts{}, false
+	}
+	raw, err := file.GetCellValue(sheet, cell, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return time.Time{}, dateParts{}, false
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		parsed, err = time.Parse("2006-01-02T15:04:05", raw)
+	}
+	return parsed, dateParts{hasDate: true, hasTime: true}, err == nil
+}
+
+func formatCellTime(t time.Time, parts dateParts, pattern string) string {
+	if pattern == defaultDateFormat {
+		switch {
+		case !parts.hasTime:
+			return t.Format("2006-01-02")
+		case !parts.hasDate:
+			return t.Format("15:04:05")
+		}
+		return t.Format("2006-01-02T15:04:05")
+	}
+	return t.Format(dateLayout(pattern))
+}
+
+func dateValue(file *excelize.File, sheet, cell string) (string, bool) {
+	if display.dateFormat == "excel" || display.valueMode == "raw" {
+		return "", false
+	}
+	t, parts, ok := cellTime(file, sheet, cell)
+	if !ok {
+		return "", false
+	}
+	return formatCellTime(t, parts, display.dateFormat), true
+}
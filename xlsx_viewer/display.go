@@ -0,0 +1,108 @@
+package main
+
+import (
+	"github.com/xuri/excelize/v2"
+)
+
+type displayOptions struct {
+	formulas   bool
+	calc       bool
+	valueMode  string
+	dateFormat string
+	merged     string
+	comments   bool
+	skipHidden bool
+	markHidden bool
+	richText   string
+	hyperlinks bool
+}
+
+var display displayOptions
+
+func configureDisplay(opts options) {
+	display = displayOptions{
+		formulas:   opts.formulas,
+		calc:       opts.calc,
+		valueMode:  opts.valueMode,
+		dateFormat: opts.dateFormat,
+		merged:     opts.merged,
+		comments:   opts.comments,
+		skipHidden: opts.skipHidden,
+		markHidden: opts.markHidden,
+		richText:   opts.richText,
+		hyperlinks: opts.hyperlinks,
+	}
+}
+
+func displayValue(file *excelize.File, sheet string, row, col int) (string, error) {
+	value, err := cellValue(file, sheet, row, col)
+	if err != nil {
+		return "", err
+	}
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return "", err
+	}
+	if display.richText != "" {
+		rich, ok, err := richTextValue(file, sheet, cell)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			value = rich
+		}
+	}
+	if display.merged == "mark" {
+		if area, ok := findMergedArea(file, sheet, row, col); ok {
+			if cell == area.anchor {
+				value = annotate(value, "合并:"+area.ref)
+			} else {
+				value = annotate(value, "合并于"+area.anchor)
+			}
+		}
+	}
+	if display.valueMode == "both" {
+		details, err := describeValue(file, sheet, cell)
+		if err != nil {
+			return "", err
+		}
+		if details != "" {
+			value = annotate(value, details)
+		}
+	}
+	if display.formulas {
+		formula, err := file.GetCellFormula(sheet, cell)
+		if err != nil {
+			return "", err
+		}
+		if formula != "" {
+			value = annotate(value, formulaText(formula))
+		}
+	}
+	if display.comments {
+		note, err := commentNote(file, sheet, cell)
+		if err != nil {
+			return "", err
+		}
+		if note != "" {
+			value = annotate(value, note)
+		}
+	}
+	if display.hyperlinks {
+		note, err := hyperlinkNote(file, sheet, row, col)
+		if err != nil {
+			return "", err
+		}
+		if note != "" {
+			value = annotate(value, note)
+		}
+	}
+	return value, nil
+}
+
+func annotate(value, note string) string {
+	if value == "" {
+		return "{" + note + "}"
+	}
+	return value + " {" + note + "}"
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type duplicateGroup struct {
+	key  string
+	rows []int
+}
+
+func parseKeyColumns(file *excelize.File, sheet, raw string, headerRows, totalCols int) ([]int, error) {
+	cols := []int{}
+	for _, item := range strings.Split(raw, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		col, err := resolveColumn(file, sheet, item, headerRows, totalCols)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("--key 未指定有效列")
+	}
+	return cols, nil
+}
+
+func handleDuplicates(file *excelize.File, sheet string, totalRows, totalCols int, opts options) {
+	wholeRow := opts.keyRaw == ""
+	keyCols := make([]int, 0, totalCols)
+	if wholeRow {
+		for col := 1; col <= totalCols; col++ {
+			keyCols = append(keyCols, col)
+		}
+	} else {
+		parsed, err := parseKeyColumns(file, sheet, opts.keyRaw, opts.headerRows, totalCols)
+		if err != nil {
+			exitWithUsageError(err.Error())
+		}
+		keyCols = parsed
+	}
+
+	groups := []*duplicateGroup{}
+	byKey := map[string]*duplicateGroup{}
+	for row := opts.headerRows + 1; row <= totalRows; row++ {
+		if display.skipHidden && rowHidden(file, sheet, row) {
+			continue
+		}
+		values := make([]string, len(keyCols))
+		for i, col := range keyCols {
+			value, err := cellValue(file, sheet, row, col)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+			values[i] = value
+		}
+		if strings.TrimSpace(strings.Join(values, "")) == "" {
+			continue
+		}
+		key := strings.Join(values, "\x00")
+		group, ok := byKey[key]
+		if !ok {
+			group = &duplicateGroup{key: strings.Join(values, "|")}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.rows = append(group.rows, row)
+	}
+
+	duplicates := []*duplicateGroup{}
+	for _, group := range groups {
+		if len(group.rows) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+	if !printLine("重复检查: 找到 %d 组重复", len(duplicates)) {
+		printOutputTruncated()
+		return
+	}
+	if len(duplicates) > opts.limit {
+		printWarning(fmt.Sprintf("只显示前 %d 组, 使用 --limit 查看更多", opts.limit))
+		duplicates = duplicates[:opts.limit]
+	}
+
+	colIndexes := leadingCols(file, sheet, totalCols, opts.maxCols)
+	for i, group := range duplicates {
+		if limits.truncated {
+			break
+		}
+		rowLabels := make([]string, len(group.rows))
+		for j, row := range group.rows {
+			rowLabels[j] = strconv.Itoa(row)
+		}
+		header := fmt.Sprintf("第%d组: 键 %s, 行 %s", i+1, group.key, strings.Join(rowLabels, ","))
+		if wholeRow {
+			header = fmt.Sprintf("第%d组: 整行相同, 行 %s", i+1, strings.Join(rowLabels, ","))
+		}
+		if !printLine("%s", header) {
+			printGroupsTruncated(i, len(duplicates))
+			return
+		}
+		data := make([][]string, 0, len(group.rows))
+		for _, row := range group.rows {
+			rowValues, err := readCells(file, sheet, row, colIndexes)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+			data = append(data, rowValues)
+		}
+		printGridData(file, sheet, data, group.rows, colIndexes)
+	}
+}
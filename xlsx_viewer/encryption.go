@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/richardlehane/mscfb"
+	"github.com/xuri/excelize/v2"
+)
+
+const passwordEnv = "XLSX_VIEWER_PASSWORD"
+
+var (
+	zipSignature = []byte("PK\x03\x04")
+	oleSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+)
+
+// encryptionStreamName is the compound file stream Office adds when it
+// encrypts a workbook.
+const encryptionStreamName = "EncryptionInfo"
+
+func readFileHead(path string, size int) []byte {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	head := make([]byte, size)
+	n, _ := io.ReadFull(file, head)
+	return head[:n]
+}
+
+// isEncryptedWorkbook walks the compound file directory only; no stream is
+// read.
+func isEncryptedWorkbook(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	head := make([]byte, len(oleSignature))
+	if _, err := io.ReadFull(file, head); err != nil || !bytes.Equal(head, oleSignature) {
+		return false
+	}
+	doc, err := mscfb.New(file)
+	if err != nil {
+		return false
+	}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		if entry.Name == encryptionStreamName {
+			return true
+		}
+	}
+	return false
+}
+
+// describeOpenError tells an encrypted workbook (password missing or wrong)
+// apart from a damaged zip or a file that is not a workbook at all.
+func describeOpenError(path, password string, err error) error {
+	head := readFileHead(path, len(oleSignature))
+	switch {
+	case bytes.HasPrefix(head, oleSignature) && isEncryptedWorkbook(path):
+		if password == "" {
+			return fmt.Errorf("文件已加密, 需要密码: %s (使用 --password 或环境变量 %s)", path, passwordEnv)
+		}
+		if errors.Is(err, excelize.ErrWorkbookPassword) || errors.Is(err, excelize.ErrWorkbookFileFormat) {
+			return fmt.Errorf("文件已加密, 密码不正确或加密方式不受支持: %s", path)
+		}
+		return fmt.Errorf("文件已加密, 解密失败: %s (%s)", path, err.Error())
+	case bytes.HasPrefix(head, zipSignature):
+		return fmt.Errorf("文件已损坏: %s (%s)", path, err.Error())
+	default:
+		return fmt.Errorf("无法打开文件: %s (不是有效的 xlsx 文件)", path)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var supportedExtensions = []string{".xlsx", ".xlsm", ".xltx", ".xltm", ".xls", ".ods", ".csv", ".tsv"}
+
+type workbookKind struct {
+	extension   string
+	description string
+	macros      bool
+}
+
+var workbookContentTypes = map[string]workbookKind{
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml":    {".xlsx", "工作簿", false},
+	"application/vnd.ms-excel.sheet.macroEnabled.main+xml":                          {".xlsm", "启用宏的工作簿", true},
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.template.main+xml": {".xltx", "模板", false},
+	"application/vnd.ms-excel.template.macroEnabled.main+xml":                       {".xltm", "启用宏的模板", true},
+}
+
+type contentTypes struct {
+	Overrides []struct {
+		PartName    string `xml:"PartName,attr"`
+		ContentType string `xml:"ContentType,attr"`
+	} `xml:"Override"`
+}
+
+func isSupportedExtension(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, supported := range supportedExtensions {
+		if ext == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// detectWorkbookKind reads the workbook part's content type from
+// [Content_Types].xml, so the real format is known regardless of extension.
+func detectWorkbookKind(file *excelize.File) (workbookKind, error) {
+	if kind, ok := loadedSources[file.Path]; ok {
+		return kind, nil
+	}
+	content, err := packagePart(file, "[Content_Types].xml")
+	if err != nil {
+		return workbookKind{}, err
+	}
+	var types contentTypes
+	if err := xml.Unmarshal(content, &types); err != nil {
+		return workbookKind{}, err
+	}
+	for _, override := range types.Overrides {
+		if kind, ok := workbookContentTypes[override.ContentType]; ok {
+			return kind, nil
+		}
+	}
+	for _, override := range types.Overrides {
+		if strings.HasSuffix(override.ContentType, ".main+xml") {
+			return workbookKind{}, fmt.Errorf("不是 Excel 工作簿 (内容类型: %s)", override.ContentType)
+		}
+	}
+	return workbookKind{}, fmt.Errorf("不是 Excel 工作簿 (缺少工作簿内容类型)")
+}
+
+func macroSummary(file *excelize.File, kind workbookKind) string {
+	content, ok := file.Pkg.Load(vbaProjectPart)
+	if !ok {
+		if kind.macros {
+			return "无 (启用宏的格式, 但不含宏代码)"
+		}
+		return "无"
+	}
+	size := 0
+	if data, ok := content.([]byte); ok {
+		size = len(data)
+	}
+	return fmt.Sprintf("有 (%s, %d 字节, 只报告不执行)", vbaProjectPart, size)
+}
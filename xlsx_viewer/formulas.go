@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// sheetExtent widens the data extent from sheetSize with the sheet's declared
+// dimension, since formula cells without cached values do not show up in
+// GetRows.
+func sheetExtent(file *excelize.File, sheet string, totalRows, totalCols int) (int, int) {
+	ref, err := file.GetSheetDimension(sheet)
+	if err != nil || ref == "" {
+		return totalRows, totalCols
+	}
+	parts := strings.Split(ref, ":")
+	col, row, err := excelize.CellNameToCoordinates(parts[len(parts)-1])
+	if err != nil {
+		return totalRows, totalCols
+	}
+	if row > totalRows {
+		totalRows = row
+	}
+	if col > totalCols {
+		totalCols = col
+	}
+	return totalRows, totalCols
+}
+
+func formulaText(formula string) string {
+	if strings.HasPrefix(formula, "=") {
+		return formula
+	}
+	return "=" + formula
+}
+
+func handleListFormulas(file *excelize.File, sheet string, totalRows, totalCols int, opts options) {
+	maxRow, maxCol := sheetExtent(file, sheet, totalRows, totalCols)
+	results := [][]string{}
+	for row := 1; row <= maxRow; row++ {
+		for col := 1; col <= maxCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+			formula, err := file.GetCellFormula(sheet, cell)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+			if formula == "" {
+				continue
+			}
+			value, err := cellValue(file, sheet, row, col)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+			line := []string{cell, formulaText(formula), truncateCell(value)}
+			if opts.calc {
+				line[2] = truncateCell(cachedValue(file, sheet, cell))
+				line = append(line, truncateCell(value))
+			}
+			results = append(results, line)
+		}
+	}
+	if !printLine("公式单元格: 找到 %d 个", len(results)) {
+		printOutputTruncated()
+		return
+	}
+	if len(results) == 0 {
+		return
+	}
+	headers := []string{"单元格", "公式", "缓存值"}
+	if opts.calc {
+		headers = append(headers, "重算值")
+	}
+	printCSVRow(headers)
+	for i, line := range results {
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(results))
+			return
+		}
+	}
+}
+
+// calculatedValue evaluates the formula in cell; ok is false for plain cells
+// and for formulas excelize cannot evaluate, which keep their cached value.
+func calculatedValue(file *excelize.File, sheet, cell string, readOpts excelize.Options) (string, bool) {
+	formula, err := file.GetCellFormula(sheet, cell)
+	if err != nil || formula == "" {
+		return "", false
+	}
+	value, err := file.CalcCellValue(sheet, cell, readOpts)
+	if err != nil {
+		calcFailures.add(err.Error(), cell)
+		return "", false
+	}
+	return value, true
+}
+
+// calcFailureLog groups --calc failures by error so a function excelize does
+// not support, repeated down a column, yields one warning instead of one per
+// cell and read.
+type calcFailureLog struct {
+	errors []string
+	cells  map[string][]string
+	seen   map[string]bool
+}
+
+var calcFailures calcFailureLog
+
+func (l *calcFailureLog) add(message, cell string) {
+	if l.seen == nil {
+		l.cells = map[string][]string{}
+		l.seen = map[string]bool{}
+	}
+	if l.seen[cell] {
+		return
+	}
+	l.seen[cell] = true
+	if _, ok := l.cells[message]; !ok {
+		l.errors = append(l.errors, message)
+	}
+	l.cells[message] = append(l.cells[message], cell)
+}
+
+func reportCalcFailures() {
+	for _, message := range calcFailures.errors {
+		cells := calcFailures.cells[message]
+		if len(cells) == 1 {
+			printWarning(fmt.Sprintf("单元格 %s 公式计算失败, 使用缓存值: %s", cells[0], message))
+			continue
+		}
+		printWarning(fmt.Sprintf("%d 个单元格公式计算失败(如 %s), 使用缓存值: %s", len(cells), cells[0], message))
+	}
+}
+
+func cachedValue(file *excelize.File, sheet, cell string) string {
+	value, err := file.GetCellValue(sheet, cell)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+func sameCellValue(cached, calculated string) bool {
+	if cached == calculated {
+		return true
+	}
+	a, errA := strconv.ParseFloat(cached, 64)
+	b, errB := strconv.ParseFloat(calculated, 64)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+func handleStaleFormulas(file *excelize.File, sheet string, totalRows, totalCols int, opts options) {
+	maxRow, maxCol := sheetExtent(file, sheet, totalRows, totalCols)
+	raw := excelize.Options{RawCellValue: true}
+	checked, failed := 0, 0
+	results := [][]string{}
+	for row := 1; row <= maxRow; row++ {
+		for col := 1; col <= maxCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+			formula, err := file.GetCellFormula(sheet, cell)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+			if formula == "" {
+				continue
+			}
+			checked++
+			cached, err := file.GetCellValue(sheet, cell, raw)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+			calculated, err := file.CalcCellValue(sheet, cell, raw)
+			if err != nil {
+				failed++
+				results = append(results, []string{cell, formulaText(formula), truncateCell(cached), "", "计算失败: " + err.Error()})
+				continue
+			}
+			if !sameCellValue(cached, calculated) {
+				results = append(results, []string{cell, formulaText(formula), truncateCell(cached), truncateCell(calculated), ""})
+			}
+		}
+	}
+	if !printLine("公式重算: 检查 %d 个公式, %d 个缓存值过期, %d 个无法计算", checked, len(results)-failed, failed) {
+		printOutputTruncated()
+		return
+	}
+	if len(results) == 0 {
+		return
+	}
+	printCSVRow([]string{"单元格", "公式", "缓存值", "重算值", "备注"})
+	for i, line := range results {
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(results))
+			return
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func rowHidden(file *excelize.File, sheet string, row int) bool {
+	visible, err := file.GetRowVisible(sheet, row)
+	return err == nil && !visible
+}
+
+func colHidden(file *excelize.File, sheet string, col int) bool {
+	visible, err := file.GetColVisible(sheet, numberToColumn(col))
+	return err == nil && !visible
+}
+
+func visibleRows(file *excelize.File, sheet string, rows []int) []int {
+	if !display.skipHidden {
+		return rows
+	}
+	result := make([]int, 0, len(rows))
+	for _, row := range rows {
+		if !rowHidden(file, sheet, row) {
+			result = append(result, row)
+		}
+	}
+	return result
+}
+
+func visibleCols(file *excelize.File, sheet string, cols []int) []int {
+	if !display.skipHidden {
+		return cols
+	}
+	result := make([]int, 0, len(cols))
+	for _, col := range cols {
+		if !colHidden(file, sheet, col) {
+			result = append(result, col)
+		}
+	}
+	return result
+}
+
+// leadingRows returns the first maxRows rows of the sheet, counting only
+// visible rows when --skip-hidden is set.
+func leadingRows(file *excelize.File, sheet string, totalRows, maxRows int) []int {
+	rows := []int{}
+	for row := 1; row <= totalRows && len(rows) < maxRows; row++ {
+		if display.skipHidden && rowHidden(file, sheet, row) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func leadingCols(file *excelize.File, sheet string, totalCols, maxCols int) []int {
+	cols := []int{}
+	for col := 1; col <= totalCols && len(cols) < maxCols; col++ {
+		if display.skipHidden && colHidden(file, sheet, col) {
+			continue
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+func rowLabel(file *excelize.File, sheet string, row int) string {
+	label := strconv.Itoa(row)
+	if !display.markHidden {
+		return label
+	}
+	level, _ := file.GetRowOutlineLevel(sheet, row)
+	return label + visibilityNote(rowHidden(file, sheet, row), level)
+}
+
+func columnLabel(file *excelize.File, sheet string, col int) string {
+	return markColumn(file, sheet, col, numberToColumn(col))
+}
+
+func markColumn(file *excelize.File, sheet string, col int, label string) string {
+	if !display.markHidden {
+		return label
+	}
+	level, _ := file.GetColOutlineLevel(sheet, numberToColumn(col))
+	return label + visibilityNote(colHidden(file, sheet, col), level)
+}
+
+func visibilityNote(hidden bool, level uint8) string {
+	notes := []string{}
+	if hidden {
+		notes = append(notes, "隐藏")
+	}
+	if level > 0 {
+		notes = append(notes, fmt.Sprintf("层级%d", level))
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(notes, ",") + "]"
+}
+
+func handleSize(file *excelize.File, sheet string, totalRows, totalCols int) {
+	printSize(totalRows, totalCols)
+	layout, err := scanSheetLayout(file, sheet, totalRows, totalCols)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	if len(layout.hiddenRows) > 0 {
+		printLine("HiddenRows:%s", formatIndexList(layout.hiddenRows, strconv.Itoa))
+	}
+	if len(layout.hiddenCols) > 0 {
+		printLine("HiddenCols:%s", formatIndexList(layout.hiddenCols, numberToColumn))
+	}
+	printAutoFilter(layout.filter)
+	if limits.truncated {
+		printOutputTruncated()
+	}
+}
+
+// sheetLayout is what --size reports besides the dimensions.
+type sheetLayout struct {
+	hiddenRows []int
+	hiddenCols []int
+	filter     *autoFilter
+}
+
+// scanSheetLayout reads hidden rows, hidden columns and the autofilter in one
+// pass over the worksheet part; asking GetRowVisible row by row is several
+// times slower on large sheets.
+func scanSheetLayout(file *excelize.File, sheet string, totalRows, totalCols int) (sheetLayout, error) {
+	layout := sheetLayout{}
+	name, err := sheetPartName(file, sheet)
+	if err != nil {
+		return layout, err
+	}
+	content, err := packagePart(file, name)
+	if err != nil {
+		return layout, err
+	}
+	row := 0
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			return layout, nil
+		}
+		if err != nil {
+			return layout, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "row":
+			row++
+			if index, err := strconv.Atoi(xmlAttr(start, "r")); err == nil {
+				row = index
+			}
+			if isTrue(xmlAttr(start, "hidden")) && row <= totalRows {
+				layout.hiddenRows = append(layout.hiddenRows, row)
+			}
+		case "col":
+			if !isTrue(xmlAttr(start, "hidden")) {
+				continue
+			}
+			first, _ := strconv.Atoi(xmlAttr(start, "min"))
+			last, _ := strconv.Atoi(xmlAttr(start, "max"))
+			for col := max(first, 1); col <= min(last, totalCols); col++ {
+				layout.hiddenCols = append(layout.hiddenCols, col)
+			}
+		case "autoFilter":
+			if layout.filter != nil {
+				continue
+			}
+			// RawToken keeps no element stack, so the element is decoded
+			// again from its own offset.
+			var filter autoFilter
+			if err := xml.NewDecoder(bytes.NewReader(content[offset:])).Decode(&filter); err != nil {
+				return layout, err
+			}
+			layout.filter = &filter
+		}
+	}
+}
+
+func xmlAttr(start xml.StartElement, local string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func isTrue(value string) bool {
+	return value == "1" || value == "true"
+}
+
+func formatIndexList(indexes []int, label func(int) string) string {
+	parts := []string{}
+	for i := 0; i < len(indexes); {
+		j := i
+		for j+1 < len(indexes) && indexes[j+1] == indexes[j]+1 {
+			j++
+		}
+		if i == j {
+			parts = append(parts, label(indexes[i]))
+		} else {
+			parts = append(parts, label(indexes[i])+"-"+label(indexes[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type hyperlink struct {
+	ref      string
+	target   string
+	external bool
+	tooltip  string
+	area     cellArea
+}
+
+type sheetHyperlinkList struct {
+	Links []struct {
+		Ref      string `xml:"ref,attr"`
+		ID       string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		Location string `xml:"location,attr"`
+		Tooltip  string `xml:"tooltip,attr"`
+	} `xml:"hyperlink"`
+}
+
+var hyperlinkCache = map[string][]hyperlink{}
+
+// sheetHyperlinks reads the hyperlinks element directly, because
+// GetCellHyperLink cannot enumerate links or tell internal from external ones.
+func sheetHyperlinks(file *excelize.File, sheet string) ([]hyperlink, error) {
+	key := file.Path + "!" + sheet
+	if links, ok := hyperlinkCache[key]; ok {
+		return links, nil
+	}
+	var list sheetHyperlinkList
+	found, err := sheetElement(file, sheet, "hyperlinks", &list)
+	if err != nil {
+		return nil, err
+	}
+	links := []hyperlink{}
+	if found {
+		targets, err := sheetRelationships(file, sheet)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Links {
+			ref := strings.ToUpper(strings.ReplaceAll(item.Ref, "$", ""))
+			area, err := parseArea(ref, 0, 0)
+			if err != nil {
+				continue
+			}
+			link := hyperlink{ref: ref, target: item.Location, tooltip: item.Tooltip, area: area}
+			if item.ID != "" {
+				link.external = true
+				link.target = targets[item.ID]
+				if item.Location != "" {
+					link.target += "#" + item.Location
+				}
+			}
+			links = append(links, link)
+		}
+	}
+	hyperlinkCache[key] = links
+	return links, nil
+}
+
+func findHyperlink(file *excelize.File, sheet string, row, col int) (hyperlink, bool, error) {
+	links, err := sheetHyperlinks(file, sheet)
+	if err != nil {
+		return hyperlink{}, false, err
+	}
+	for _, link := range links {
+		if row >= link.area.startRow && row <= link.area.endRow && col >= link.area.startCol && col <= link.area.endCol {
+			return link, true, nil
+		}
+	}
+	return hyperlink{}, false, nil
+}
+
+func hyperlinkNote(file *excelize.File, sheet string, row, col int) (string, error) {
+	link, ok, err := findHyperlink(file, sheet, row, col)
+	if err != nil || !ok {
+		return "", err
+	}
+	return "链接:" + link.target, nil
+}
+
+func (l hyperlink) kind() string {
+	if l.external {
+		return "外部"
+	}
+	return "内部"
+}
+
+func handleListLinks(file *excelize.File, sheet string) {
+	links, err := sheetHyperlinks(file, sheet)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	if !printLine("超链接: 共 %d 个", len(links)) {
+		printOutputTruncated()
+		return
+	}
+	if len(links) == 0 {
+		return
+	}
+	printCSVRow([]string{"单元格", "类型", "目标", "提示", "单元格值"})
+	for i, link := range links {
+		value, err := cellValue(file, sheet, link.area.startRow, link.area.startCol)
+		if err != nil {
+			exitWithError(err.Error())
+		}
+		line := []string{link.ref, link.kind(), link.target, truncateCell(link.tooltip), truncateCell(value)}
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(links))
+			return
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type idLocation struct {
+	path string
+	row  int
+}
+
+type idRange struct {
+	start int
+	end   int
+}
+
+// idRangePattern allows negative bounds, so the dash between them cannot
+// simply be split on.
+var idRangePattern = regexp.MustCompile(`^(-?\d+)\s*-\s*(-?\d+)$`)
+
+func parseIDRange(input string) (idRange, error) {
+	match := idRangePattern.FindStringSubmatch(strings.TrimSpace(input))
+	if match == nil {
+		return idRange{}, fmt.Errorf("无效ID区间: %s", input)
+	}
+	start, err := strconv.Atoi(match[1])
+	if err != nil {
+		return idRange{}, fmt.Errorf("无效ID区间: %s", input)
+	}
+	end, err := strconv.Atoi(match[2])
+	if err != nil {
+		return idRange{}, fmt.Errorf("无效ID区间: %s", input)
+	}
+	if start > end {
+		start, end = end, start
+	}
+	return idRange{start: start, end: end}, nil
+}
+
+func collectIDs(file *excelize.File, sheet, path, spec string, headerRows int, ids map[int][]idLocation) (int, error) {
+	totalRows, totalCols, err := sheetSize(file, sheet)
+	if err != nil {
+		return 0, err
+	}
+	colIdx, err := resolveColumn(file, sheet, spec, headerRows, totalCols)
+	if err != nil {
+		return 0, err
+	}
+	invalid := 0
+	for row := headerRows + 1; row <= totalRows; row++ {
+		// The raw value keeps IDs shown with a thousands separator readable.
+		cell, _ := excelize.CoordinatesToCellName(colIdx, row)
+		value, err := file.GetCellValue(sheet, cell, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return 0, err
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			invalid++
+			continue
+		}
+		ids[id] = append(ids[id], idLocation{path: path, row: row})
+	}
+	return invalid, nil
+}
+
+func handleIDGaps(file *excelize.File, sheet string, opts options) {
+	ids := map[int][]idLocation{}
+	invalid, err := collectIDs(file, sheet, opts.path, opts.idCol, opts.headerRows, ids)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	for _, path := range opts.idFiles {
+		if err := validatePath(path); err != nil {
+			exitWithError(err.Error())
+		}
+		other, otherSheet, err := openWorkbook(path, opts)
+		if err != nil {
+			exitWithError(err.Error())
+		}
+		skipped, err := collectIDs(other, otherSheet, path, opts.idCol, opts.headerRows, ids)
+		_ = other.Close()
+		if err != nil {
+			exitWithError(fmt.Sprintf("%s: %s", path, err.Error()))
+		}
+		invalid += skipped
+	}
+	if invalid > 0 {
+		printWarning(fmt.Sprintf("跳过 %d 个非整数ID", invalid))
+	}
+
+	sorted := make([]int, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Ints(sorted)
+
+	bounds := opts.idRange
+	if !opts.hasIDRange {
+		if len(sorted) == 0 {
+			printLine("ID统计: 共 0 个ID")
+			return
+		}
+		bounds = idRange{start: sorted[0], end: sorted[len(sorted)-1]}
+	}
+
+	used := map[int]bool{}
+	inRange := []int{}
+	for _, id := range sorted {
+		if id >= bounds.start && id <= bounds.end {
+			used[id] = true
+			inRange = append(inRange, id)
+		}
+	}
+	free := []idRange{}
+	freeCount := 0
+	cursor := bounds.start
+	for _, id := range inRange {
+		if id > cursor {
+			free = append(free, idRange{start: cursor, end: id - 1})
+			freeCount += id - cursor
+		}
+		cursor = id + 1
+	}
+	if cursor <= bounds.end {
+		free = append(free, idRange{start: cursor, end: bounds.end})
+		freeCount += bounds.end - cursor + 1
+	}
+
+	duplicates := []int{}
+	for _, id := range inRange {
+		if len(ids[id]) > 1 {
+			duplicates = append(duplicates, id)
+		}
+	}
+
+	if !printLine("ID统计: 区间 %d-%d, 已用 %d 个, 重复 %d 个, 空闲 %d 个", bounds.start, bounds.end, len(inRange), len(duplicates), freeCount) {
+		printOutputTruncated()
+		return
+	}
+	if outside := len(sorted) - len(inRange); outside > 0 {
+		printWarning(fmt.Sprintf("%d 个ID不在区间 %d-%d 内", outside, bounds.start, bounds.end))
+	}
+
+	if !printLine("重复ID: %d 个", len(duplicates)) {
+		printOutputTruncated()
+		return
+	}
+	if len(duplicates) > 0 {
+		if !printCSVRow([]string{"ID", "次数", "位置"}) {
+			printRowsTruncated(0, len(duplicates))
+			return
+		}
+		for i, id := range duplicates {
+			if !printCSVRow([]string{strconv.Itoa(id), strconv.Itoa(len(ids[id])), formatIDLocations(ids[id], len(opts.idFiles) > 0)}) {
+				printRowsTruncated(i, len(duplicates))
+				return
+			}
+		}
+	}
+
+	if !printLine("空闲区间: %d 段", len(free)) {
+		printOutputTruncated()
+		return
+	}
+	for i, r := range free {
+		if !printLine("%s", formatIDRange(r)) {
+			printRowsTruncated(i, len(free))
+			return
+		}
+	}
+
+	if opts.nextIDs > 0 {
+		allocBounds := bounds
+		if !opts.hasIDRange {
+			allocBounds.end += opts.nextIDs
+		}
+		next := allocateIDs(inRange, allocBounds, used, opts.nextIDs)
+		if len(next) < opts.nextIDs {
+			printWarning(fmt.Sprintf("区间 %d-%d 内只剩 %d 个空闲ID", bounds.start, bounds.end, len(next)))
+		}
+		values := make([]string, len(next))
+		for i, id := range next {
+			values[i] = strconv.Itoa(id)
+		}
+		if !printLine("下一个可用ID: %s", strings.Join(values, ",")) {
+			printOutputTruncated()
+		}
+	}
+}
+
+// allocateIDs keeps blocks contiguous: IDs are taken after the highest used ID
+// first, and only once the block tail is exhausted are gaps back-filled.
+func allocateIDs(inRange []int, bounds idRange, used map[int]bool, count int) []int {
+	result := []int{}
+	start := bounds.start
+	if len(inRange) > 0 {
+		start = inRange[len(inRange)-1] + 1
+	}
+	for id := start; id <= bounds.end && len(result) < count; id++ {
+		result = append(result, id)
+	}
+	for id := bounds.start; id < start && id <= bounds.end && len(result) < count; id++ {
+		if !used[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func formatIDRange(r idRange) string {
+	if r.start == r.end {
+		return strconv.Itoa(r.start)
+	}
+	return fmt.Sprintf("%d-%d", r.start, r.end)
+}
+
+func formatIDLocations(locations []idLocation, withPath bool) string {
+	parts := make([]string, len(locations))
+	for i, loc := range locations {
+		if withPath {
+			parts[i] = fmt.Sprintf("%s:%d", filepath.Base(loc.path), loc.row)
+		} else {
+			parts[i] = strconv.Itoa(loc.row)
+		}
+	}
+	return strings.Join(parts, ";")
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const vbaProjectPart = "xl/vbaProject.bin"
+
+func countFormulas(file *excelize.File, sheet string) (int, error) {
+	name, err := sheetPartName(file, sheet)
+	if err != nil {
+		return 0, err
+	}
+	content, err := packagePart(file, name)
+	if err != nil {
+		return 0, err
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	count := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "f" {
+			count++
+		}
+	}
+}
+
+func externalLinkTargets(file *excelize.File) []string {
+	targets := []string{}
+	for _, name := range packagePartNames(file) {
+		if !strings.HasPrefix(name, "xl/externalLinks/externalLink") || !strings.HasSuffix(name, ".xml") {
+			continue
+		}
+		rels, err := partRelationships(file, name)
+		if err != nil || len(rels) == 0 {
+			targets = append(targets, name)
+			continue
+		}
+		ids := make([]string, 0, len(rels))
+		for id := range rels {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			targets = append(targets, rels[id])
+		}
+	}
+	return targets
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "有"
+	}
+	return "无"
+}
+
+func printProperty(label, value string) {
+	if value != "" {
+		printLine("%s: %s", label, value)
+	}
+}
+
+func handleInfo(file *excelize.File) {
+	printLine("文件: %s", file.Path)
+	kind, err := detectWorkbookKind(file)
+	if err == nil {
+		printLine("类型: %s (%s)", strings.TrimPrefix(kind.extension, "."), kind.description)
+	}
+	if props, err := file.GetDocProps(); err == nil {
+		printProperty("标题", props.Title)
+		printProperty("主题", props.Subject)
+		printProperty("创建者", props.Creator)
+		printProperty("最后修改者", props.LastModifiedBy)
+		printProperty("创建时间", props.Created)
+		printProperty("修改时间", props.Modified)
+		printProperty("版本", props.Version)
+		printProperty("修订号", props.Revision)
+		printProperty("关键词", props.Keywords)
+		printProperty("类别", props.Category)
+		printProperty("备注", props.Description)
+	} else {
+		printWarning(fmt.Sprintf("无法读取文档属性: %s", err.Error()))
+	}
+	if app, err := file.GetAppProps(); err == nil {
+		printProperty("应用程序", strings.TrimSpace(app.Application+" "+app.AppVersion))
+		printProperty("公司", app.Company)
+	}
+
+	sheets := file.GetSheetList()
+	totalFormulas := 0
+	lines := [][]string{}
+	for i, sheet := range sheets {
+		rows, cols, err := sheetSize(file, sheet)
+		if err != nil {
+			lines = append(lines, []string{strconv.Itoa(i + 1), sheet, "非工作表", "", "", ""})
+			continue
+		}
+		state := "可见"
+		if visible, err := file.GetSheetVisible(sheet); err == nil && !visible {
+			state = "隐藏"
+		}
+		formulas, err := countFormulas(file, sheet)
+		if err != nil {
+			printWarning(fmt.Sprintf("无法统计 %s 的公式: %s", sheet, err.Error()))
+		}
+		totalFormulas += formulas
+		lines = append(lines, []string{strconv.Itoa(i + 1), sheet, state, strconv.Itoa(rows), strconv.Itoa(cols), strconv.Itoa(formulas)})
+	}
+	printLine("工作表: 共 %d 个", len(sheets))
+	printCSVRow([]string{"序号", "名称", "状态", "行数", "列数", "公式数"})
+	for _, line := range lines {
+		printCSVRow(line)
+	}
+
+	printLine("定义的名称: %d 个", len(file.GetDefinedName()))
+	tables := 0
+	for _, sheet := range sheets {
+		if list, err := file.GetTables(sheet); err == nil {
+			tables += len(list)
+		}
+	}
+	printLine("表格: %d 个", tables)
+	printLine("公式: %s (%d 个)", yesNo(totalFormulas > 0), totalFormulas)
+	printLine("宏: %s", macroSummary(file, kind))
+	links := externalLinkTargets(file)
+	printLine("外部链接: %s (%d 个)", yesNo(len(links) > 0), len(links))
+	for _, link := range links {
+		printLine("  %s", link)
+	}
+	if limits.truncated {
+		printOutputTruncated()
+	}
+}
@@ -27,20 +27,77 @@ const (
 	opCols
 	opSearchCol
 	opSearchRow
+	opProfile
+	opIDGaps
+	opDuplicates
+	opRange
+	opListFormulas
+	opStaleFormulas
+	opListMerged
+	opListComments
+	opValidations
+	opCheckValidations
+	opListLinks
+	opListPictures
+	opExtractPictures
+	opListNames
+	opListTables
+	opTable
+	opInfo
 )
 
 type options struct {
-	path     string
-	op       operation
-	rowsRaw  string
-	colsRaw  string
-	maxCols  int
-	maxRows  int
-	mode     string
-	limit    int
-	searchIx string
-	keyword  string
-	showHelp bool
+	path      string
+	password  string
+	delimiter string
+	encoding  string
+	op        operation
+	rowsRaw   string
+	colsRaw   string
+	maxCols   int
+	maxRows   int
+	mode      string
+	limit     int
+	searchIx  string
+	keyword   string
+	showHelp  bool
+
+	headerRows int
+	topN       int
+
+	idCol      string
+	idRange    idRange
+	hasIDRange bool
+	nextIDs    int
+	idFiles    []string
+
+	keyRaw string
+
+	rangeRaw   string
+	tail       int
+	pictureDir string
+	tableName  string
+
+	offset   int
+	cursor   string
+	limitSet bool
+
+	maxCellChars    int
+	maxOutputBytes  int
+	maxOutputTokens int
+
+	formulas   bool
+	calc       bool
+	valueMode  string
+	dateFormat string
+	where      []whereClause
+	merged     string
+	comments   bool
+
+	skipHidden bool
+	markHidden bool
+	richText   string
+	hyperlinks bool
 }
 
 func main() {
@@ -59,14 +116,16 @@ func main() {
 	}
 
 	if opts.op == opNone {
-		exitWithUsageError("必须指定操作类型 (--size, --rows, --cols, --search-col, --search-row 之一)")
+		exitWithUsageError("必须指定操作类型, 如 --size, --rows, --info")
 	}
+	configureOutput(opts)
+	configureDisplay(opts)
 
 	if err := validatePath(opts.path); err != nil {
 		exitWithError(err.Error())
 	}
 
-	file, sheetName, err := openWorkbook(opts.path)
+	file, sheetName, err := openWorkbook(opts.path, opts)
 	if err != nil {
 		exitWithError(err.Error())
 	}
@@ -81,7 +140,7 @@ func main() {
 
 	switch opts.op {
 	case opSize:
-		printSize(rows, cols)
+		handleSize(file, sheetName, rows, cols)
 	case opRows:
 		handleRows(file, sheetName, rows, cols, opts)
 	case opCols:
@@ -90,9 +149,44 @@ func main() {
 		handleSearchColumn(file, sheetName, rows, cols, opts)
 	case opSearchRow:
 		handleSearchRow(file, sheetName, rows, cols, opts)
+	case opProfile:
+		handleProfile(file, sheetName, rows, cols, opts)
+	case opIDGaps:
+		handleIDGaps(file, sheetName, opts)
+	case opDuplicates:
+		handleDuplicates(file, sheetName, rows, cols, opts)
+	case opRange:
+		handleRange(file, sheetName, rows, cols, opts)
+	case opListFormulas:
+		handleListFormulas(file, sheetName, rows, cols, opts)
+	case opStaleFormulas:
+		handleStaleFormulas(file, sheetName, rows, cols, opts)
+	case opListMerged:
+		handleListMerged(file, sheetName)
+	case opListComments:
+		handleListComments(file, sheetName)
+	case opValidations:
+		handleValidations(file, sheetName, rows, cols)
+	case opCheckValidations:
+		handleCheckValidations(file, sheetName, rows, cols, opts)
+	case opListLinks:
+		handleListLinks(file, sheetName)
+	case opListPictures:
+		handleListPictures(file, sheetName)
+	case opExtractPictures:
+		handleExtractPictures(file, sheetName, opts)
+	case opListNames:
+		handleListNames(file)
+	case opListTables:
+		handleListTables(file)
+	case opTable:
+		handleTable(file, opts)
+	case opInfo:
+		handleInfo(file)
 	default:
 		exitWithUsageError("未知的操作类型")
 	}
+	reportCalcFailures()
 }
 
 func parseArgs(args []string) (options, error) {
@@ -101,6 +195,13 @@ func parseArgs(args []string) (options, error) {
 		maxRows: defaultMaxRows,
 		mode:    "fuzzy",
 		limit:   defaultLimit,
+
+		headerRows: defaultHeaderRows,
+		topN:       defaultTopN,
+
+		valueMode:  "formatted",
+		dateFormat: defaultDateFormat,
+		merged:     "fill",
 	}
 
 	if len(args) == 0 {
@@ -121,21 +222,53 @@ func parseArgs(args []string) (options, error) {
 			}
 			opts.path = value
 			i = next
+		case "--password":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			opts.password = value
+			i = next
+		case "--delimiter":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			if _, err := parseDelimiter(value); err != nil {
+				return opts, err
+			}
+			opts.delimiter = value
+			i = next
+		case "--encoding":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			encoding, ok := textEncodings[strings.ToLower(value)]
+			if !ok {
+				return opts, fmt.Errorf("无效的编码: %s (可选 utf-8, gbk, utf-16)", value)
+			}
+			opts.encoding = encoding
+			i = next
 		case "--size":
 			if err := setOperation(&opts, opSize); err != nil {
 				return opts, err
 			}
 			i++
 		case "--rows":
-			if err := setOperation(&opts, opRows); err != nil {
+			if opts.op == opCols {
+				opts.op = opRows
+			} else if err := setOperation(&opts, opRows); err != nil {
 				return opts, err
 			}
 			value, next := readOptionalRange(args, i, true)
 			opts.rowsRaw = value
 			i = next
 		case "--cols":
-			if err := setOperation(&opts, opCols); err != nil {
-				return opts, err
+			if opts.op != opRows && opts.op != opTable {
+				if err := setOperation(&opts, opCols); err != nil {
+					return opts, err
+				}
 			}
 			value, next := readOptionalRange(args, i, false)
 			opts.colsRaw = value
@@ -185,6 +318,7 @@ func parseArgs(args []string) (options, error) {
 				return opts, err
 			}
 			opts.limit = parsed
+			opts.limitSet = true
 			i = next
 		case "--search-col":
 			if err := setOperation(&opts, opSearchCol); err != nil {
@@ -216,17 +350,341 @@ func parseArgs(args []string) (options, error) {
 			opts.searchIx = value
 			opts.keyword = keyword
 			i = next2
+		case "--profile":
+			if err := setOperation(&opts, opProfile); err != nil {
+				return opts, err
+			}
+			value, next := readOptionalRange(args, i, false)
+			opts.colsRaw = value
+			i = next
+		case "--header-rows":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			parsed, err := parseNonNegativeInt(value, "--header-rows")
+			if err != nil {
+				return opts, err
+			}
+			opts.headerRows = parsed
+			i = next
+		case "--top":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			parsed, err := parsePositiveInt(value, "--top")
+			if err != nil {
+				return opts, err
+			}
+			opts.topN = parsed
+			i = next
+		case "--id-gaps":
+			if err := setOperation(&opts, opIDGaps); err != nil {
+				return opts, err
+			}
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			opts.idCol = value
+			i = next
+		case "--id-range":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			parsed, err := parseIDRange(value)
+			if err != nil {
+				return opts, err
+			}
+			opts.idRange = parsed
+			opts.hasIDRange = true
+			i = next
+		case "--next-id":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			parsed, err := parsePositiveInt(value, "--next-id")
+			if err != nil {
+				return opts, err
+			}
+			opts.nextIDs = parsed
+			i = next
+		case "--id-files":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			for _, path := range strings.Split(value, ",") {
+				if path = strings.TrimSpace(path); path != "" {
+					opts.idFiles = append(opts.idFiles, path)
+				}
+			}
+			i = next
+		case "--duplicates":
+			if err := setOperation(&opts, opDuplicates); err != nil {
+				return opts, err
+			}
+			i++
+		case "--key":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			opts.keyRaw = value
+			i = next
+		case "--tail":
+			if opts.op == opCols {
+				opts.op = opRows
+			} else if err := setOperation(&opts, opRows); err != nil {
+				return opts, err
+			}
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			parsed, err := parsePositiveInt(value, "--tail")
+			if err != nil {
+				return opts, err
+			}
+			opts.tail = parsed
+			i = next
+		case "--offset":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			parsed, err := parseNonNegativeInt(value, "--offset")
+			if err != nil {
+				return opts, err
+			}
+			opts.offset = parsed
+			i = next
+		case "--cursor":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			opts.cursor = value
+			i = next
+		case "--max-cell-chars":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			parsed, err := parsePositiveInt(value, "--max-cell-chars")
+			if err != nil {
+				return opts, err
+			}
+			opts.maxCellChars = parsed
+			i = next
+		case "--max-output-bytes":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			parsed, err := parsePositiveInt(value, "--max-output-bytes")
+			if err != nil {
+				return opts, err
+			}
+			opts.maxOutputBytes = parsed
+			i = next
+		case "--max-output-tokens":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			parsed, err := parsePositiveInt(value, "--max-output-tokens")
+			if err != nil {
+				return opts, err
+			}
+			opts.maxOutputTokens = parsed
+			i = next
+		case "--formulas":
+			opts.formulas = true
+			i++
+		case "--list-formulas":
+			if err := setOperation(&opts, opListFormulas); err != nil {
+				return opts, err
+			}
+			i++
+		case "--calc":
+			opts.calc = true
+			i++
+		case "--values":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			mode, err := parseValueMode(value)
+			if err != nil {
+				return opts, err
+			}
+			opts.valueMode = mode
+			i = next
+		case "--date-format":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			opts.dateFormat = value
+			i = next
+		case "--merged":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			mode, err := parseMergedMode(value)
+			if err != nil {
+				return opts, err
+			}
+			opts.merged = mode
+			i = next
+		case "--list-merged":
+			if err := setOperation(&opts, opListMerged); err != nil {
+				return opts, err
+			}
+			i++
+		case "--comments":
+			opts.comments = true
+			i++
+		case "--list-comments":
+			if err := setOperation(&opts, opListComments); err != nil {
+				return opts, err
+			}
+			i++
+		case "--validations":
+			if err := setOperation(&opts, opValidations); err != nil {
+				return opts, err
+			}
+			i++
+		case "--check-validations":
+			if err := setOperation(&opts, opCheckValidations); err != nil {
+				return opts, err
+			}
+			i++
+		case "--skip-hidden":
+			opts.skipHidden = true
+			i++
+		case "--mark-hidden":
+			opts.markHidden = true
+			i++
+		case "--rich-text":
+			value, next := optionalValue(args, i)
+			mode, err := parseRichTextMode(value)
+			if err != nil {
+				return opts, err
+			}
+			opts.richText = mode
+			i = next
+		case "--hyperlinks":
+			opts.hyperlinks = true
+			i++
+		case "--list-links":
+			if err := setOperation(&opts, opListLinks); err != nil {
+				return opts, err
+			}
+			i++
+		case "--list-pictures":
+			if err := setOperation(&opts, opListPictures); err != nil {
+				return opts, err
+			}
+			i++
+		case "--extract-pictures":
+			if err := setOperation(&opts, opExtractPictures); err != nil {
+				return opts, err
+			}
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			opts.pictureDir = value
+			i = next
+		case "--list-names":
+			if err := setOperation(&opts, opListNames); err != nil {
+				return opts, err
+			}
+			i++
+		case "--list-tables":
+			if err := setOperation(&opts, opListTables); err != nil {
+				return opts, err
+			}
+			i++
+		case "--table":
+			if opts.op == opCols {
+				opts.op = opTable
+			} else if err := setOperation(&opts, opTable); err != nil {
+				return opts, err
+			}
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			opts.tableName = value
+			i = next
+		case "--info":
+			if err := setOperation(&opts, opInfo); err != nil {
+				return opts, err
+			}
+			i++
+		case "--where":
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			clause, err := parseWhere(value)
+			if err != nil {
+				return opts, err
+			}
+			opts.where = append(opts.where, clause)
+			i = next
+		case "--stale-formulas":
+			if err := setOperation(&opts, opStaleFormulas); err != nil {
+				return opts, err
+			}
+			i++
+		case "--range":
+			if err := setOperation(&opts, opRange); err != nil {
+				return opts, err
+			}
+			value, next, err := nextValue(args, i)
+			if err != nil {
+				return opts, err
+			}
+			opts.rangeRaw = value
+			i = next
 		default:
 			return opts, fmt.Errorf("未知参数: %s", arg)
 		}
 	}
 
+	if len(opts.where) > 0 && opts.op != opRows && opts.op != opSearchCol && opts.op != opTable && opts.op != opNone {
+		return opts, errWhereUnsupported
+	}
+
+	if opts.password == "" {
+		opts.password = os.Getenv(passwordEnv)
+	}
+
+	if opts.cursor != "" {
+		if opts.offset > 0 {
+			return opts, errors.New("--cursor 和 --offset 不能同时使用")
+		}
+		offset, err := decodeCursor(opts, opts.cursor)
+		if err != nil {
+			return opts, err
+		}
+		opts.offset = offset
+	}
+
 	return opts, nil
 }
 
 func setOperation(opts *options, op operation) error {
 	if opts.op != opNone {
-		return errors.New("只能指定一个操作类型 (--size, --rows, --cols, --search-col, --search-row 之一)")
+		return errors.New("只能指定一个操作类型")
 	}
 	opts.op = op
 	return nil
@@ -259,11 +717,11 @@ func readOptionalRange(args []string, i int, numeric bool) (string, int) {
 	}
 	second := args[next]
 	if numeric {
-		if _, err := strconv.Atoi(second); err != nil {
+		if _, err := strconv.Atoi(second); err != nil && !isEndKeyword(second) {
 			return first, next
 		}
 	} else {
-		if _, ok := parseColumnIndex(second); !ok {
+		if _, ok := parseColumnIndex(second); !ok && !isEndKeyword(second) {
 			return first, next
 		}
 	}
@@ -278,6 +736,14 @@ func parsePositiveInt(value, flag string) (int, error) {
 	return parsed, nil
 }
 
+func parseNonNegativeInt(value, flag string) (int, error) {
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("%s 需要非负整数", flag)
+	}
+	return parsed, nil
+}
+
 func validatePath(path string) error {
 	info, err := os.Stat(path)
 	if err != nil {
@@ -289,16 +755,36 @@ func validatePath(path string) error {
 	if info.IsDir() {
 		return fmt.Errorf("路径是目录: %s", path)
 	}
-	if strings.ToLower(filepath.Ext(path)) != ".xlsx" {
-		return fmt.Errorf("不是有效的 xlsx 文件: %s", path)
+	if !isSupportedExtension(path) {
+		return fmt.Errorf("不支持的文件类型: %s (支持 %s)", path, strings.Join(supportedExtensions, ", "))
 	}
 	return nil
 }
 
-func openWorkbook(path string) (*excelize.File, string, error) {
-	file, err := excelize.OpenFile(path)
-	if err != nil {
-		return nil, "", fmt.Errorf("无法打开文件: %s", path)
+func openWorkbook(path string, opts options) (*excelize.File, string, error) {
+	var file *excelize.File
+	var err error
+	if isTextTable(path) {
+		reader, err := newCSVReader(path, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		if file, err = loadSourceWorkbook(path, reader); err != nil {
+			return nil, "", fmt.Errorf("无法读取文本表格: %s (%s)", path, err.Error())
+		}
+	} else if isODSFile(path) {
+		if file, err = loadSourceWorkbook(path, odsReader{}); err != nil {
+			return nil, "", fmt.Errorf("无法读取 ods 文件: %s (%s)", path, err.Error())
+		}
+		warnExtensionMismatch(path, loadedSources[path])
+	} else if isXLSFile(path) {
+		file, err = loadSourceWorkbook(path, xlsReader{})
+		if err != nil {
+			return nil, "", fmt.Errorf("无法读取 xls 文件: %s (%s)", path, err.Error())
+		}
+		warnExtensionMismatch(path, loadedSources[path])
+	} else if file, err = openExcelFile(path, opts.password); err != nil {
+		return nil, "", err
 	}
 	sheets := file.GetSheetList()
 	if len(sheets) == 0 {
@@ -307,6 +793,26 @@ func openWorkbook(path string) (*excelize.File, string, error) {
 	return file, sheets[0], nil
 }
 
+func openExcelFile(path, password string) (*excelize.File, error) {
+	file, err := excelize.OpenFile(path, excelize.Options{Password: password})
+	if err != nil {
+		return nil, describeOpenError(path, password, err)
+	}
+	kind, err := detectWorkbookKind(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("%s: %s", err.Error(), path)
+	}
+	warnExtensionMismatch(path, kind)
+	return file, nil
+}
+
+func warnExtensionMismatch(path string, kind workbookKind) {
+	if ext := strings.ToLower(filepath.Ext(path)); ext != kind.extension {
+		printWarning(fmt.Sprintf("文件扩展名为 %s, 实际内容为 %s (%s)", ext, kind.extension, kind.description))
+	}
+}
+
 func sheetSize(file *excelize.File, sheet string) (int, int, error) {
 	rows, err := file.GetRows(sheet)
 	if err != nil {
@@ -332,26 +838,44 @@ func sheetSize(file *excelize.File, sheet string) (int, int, error) {
 }
 
 func handleRows(file *excelize.File, sheet string, totalRows, totalCols int, opts options) {
-	rowIndexes, requestedMax, err := parseRowSelection(opts.rowsRaw, totalRows)
+	rowIndexes, requestedMax, err := parseRowSelection(opts.rowsRaw, opts.tail, totalRows)
 	if err != nil {
 		exitWithUsageError(err.Error())
 	}
 	if requestedMax > totalRows {
 		printWarning(fmt.Sprintf("请求%d行，但文件只有%d行", requestedMax, totalRows))
 	}
-	maxCols := opts.maxCols
-	if totalCols < maxCols {
-		maxCols = totalCols
+	rowIndexes = visibleRows(file, sheet, rowIndexes)
+	rowIndexes = filterRowsWhere(file, sheet, rowIndexes, opts, totalCols)
+	pageSize := 0
+	if opts.limitSet {
+		pageSize = opts.limit
+	}
+	rowIndexes, pg := applyPage(rowIndexes, opts, pageSize)
+	printPageInfo(pg, "行")
+	defer printPageFooter(pg, opts)
+	var colIndexes []int
+	if opts.colsRaw != "" {
+		selected, requestedCols, err := parseColumnRange(opts.colsRaw, totalCols)
+		if err != nil {
+			exitWithUsageError(err.Error())
+		}
+		if requestedCols > totalCols {
+			printWarning(fmt.Sprintf("请求%d列，但文件只有%d列", requestedCols, totalCols))
+		}
+		colIndexes = visibleCols(file, sheet, selected)
+	} else {
+		colIndexes = leadingCols(file, sheet, totalCols, opts.maxCols)
 	}
 	data := make([][]string, 0, len(rowIndexes))
 	for _, rowIdx := range rowIndexes {
-		rowValues, err := readRow(file, sheet, rowIdx, maxCols)
+		rowValues, err := readCells(file, sheet, rowIdx, colIndexes)
 		if err != nil {
 			exitWithError(err.Error())
 		}
 		data = append(data, rowValues)
 	}
-	printRowData(data, rowIndexes, maxCols)
+	printGridData(file, sheet, data, rowIndexes, colIndexes)
 }
 
 func handleCols(file *excelize.File, sheet string, totalRows, totalCols int, opts options) {
@@ -362,19 +886,24 @@ func handleCols(file *excelize.File, sheet string, totalRows, totalCols int, opt
 	if requestedMax > totalCols {
 		printWarning(fmt.Sprintf("请求%d列，但文件只有%d列", requestedMax, totalCols))
 	}
-	maxRows := opts.maxRows
-	if totalRows < maxRows {
-		maxRows = totalRows
+	colIndexes = visibleCols(file, sheet, colIndexes)
+	pageSize := 0
+	if opts.limitSet {
+		pageSize = opts.limit
 	}
+	colIndexes, pg := applyPage(colIndexes, opts, pageSize)
+	printPageInfo(pg, "列")
+	rowIndexes := leadingRows(file, sheet, totalRows, opts.maxRows)
 	data := make([][]string, 0, len(colIndexes))
 	for _, colIdx := range colIndexes {
-		colValues, err := readColumn(file, sheet, colIdx, maxRows)
+		colValues, err := readColumn(file, sheet, colIdx, rowIndexes)
 		if err != nil {
 			exitWithError(err.Error())
 		}
 		data = append(data, colValues)
 	}
-	printColumnData(data, colIndexes, maxRows)
+	printColumnData(file, sheet, data, colIndexes, rowIndexes)
+	printPageFooter(pg, opts)
 }
 
 func handleSearchColumn(file *excelize.File, sheet string, totalRows, totalCols int, opts options) {
@@ -393,41 +922,42 @@ func handleSearchColumn(file *excelize.File, sheet string, totalRows, totalCols
 		return
 	}
 	if opts.mode == "regex" {
-			if _, err := regexp.Compile(opts.keyword); err != nil {
-				exitWithError(fmt.Sprintf("正则表达式语法错误: %s", err.Error()))
-			}
+		if _, err := regexp.Compile(opts.keyword); err != nil {
+			exitWithError(fmt.Sprintf("正则表达式语法错误: %s", err.Error()))
 		}
+	}
 
 	matches := []int{}
 	for row := 1; row <= totalRows; row++ {
+		if display.skipHidden && rowHidden(file, sheet, row) {
+			continue
+		}
 		value, err := cellValue(file, sheet, row, colIdx)
 		if err != nil {
 			exitWithError(err.Error())
 		}
 		if matchValue(value, opts.keyword, opts.mode) {
 			matches = append(matches, row)
-			if len(matches) >= opts.limit {
-				break
-			}
 		}
 	}
+	matches = filterRowsWhere(file, sheet, matches, opts, totalCols)
 	printSearchResultHeader(len(matches))
+	matches, pg := applyPage(matches, opts, opts.limit)
+	printPageInfo(pg, "个")
 	if len(matches) == 0 {
 		return
 	}
-	maxCols := opts.maxCols
-	if totalCols < maxCols {
-		maxCols = totalCols
-	}
+	defer printPageFooter(pg, opts)
+	colIndexes := leadingCols(file, sheet, totalCols, opts.maxCols)
 	data := make([][]string, 0, len(matches))
 	for _, row := range matches {
-		rowValues, err := readRow(file, sheet, row, maxCols)
+		rowValues, err := readCells(file, sheet, row, colIndexes)
 		if err != nil {
 			exitWithError(err.Error())
 		}
 		data = append(data, rowValues)
 	}
-	printRowData(data, matches, maxCols)
+	printGridData(file, sheet, data, matches, colIndexes)
 }
 
 func handleSearchRow(file *excelize.File, sheet string, totalRows, totalCols int, opts options) {
@@ -448,37 +978,51 @@ func handleSearchRow(file *excelize.File, sheet string, totalRows, totalCols int
 
 	matches := []int{}
 	for col := 1; col <= totalCols; col++ {
+		if display.skipHidden && colHidden(file, sheet, col) {
+			continue
+		}
 		value, err := cellValue(file, sheet, rowIdx, col)
 		if err != nil {
 			exitWithError(err.Error())
 		}
 		if matchValue(value, opts.keyword, opts.mode) {
 			matches = append(matches, col)
-			if len(matches) >= opts.limit {
-				break
-			}
 		}
 	}
 	printSearchResultHeader(len(matches))
+	matches, pg := applyPage(matches, opts, opts.limit)
+	printPageInfo(pg, "个")
 	if len(matches) == 0 {
 		return
 	}
-	maxRows := opts.maxRows
-	if totalRows < maxRows {
-		maxRows = totalRows
-	}
+	defer printPageFooter(pg, opts)
+	rowIndexes := leadingRows(file, sheet, totalRows, opts.maxRows)
 	data := make([][]string, 0, len(matches))
 	for _, col := range matches {
-		colValues, err := readColumn(file, sheet, col, maxRows)
+		colValues, err := readColumn(file, sheet, col, rowIndexes)
 		if err != nil {
 			exitWithError(err.Error())
 		}
 		data = append(data, colValues)
 	}
-	printColumnData(data, matches, maxRows)
+	printColumnData(file, sheet, data, matches, rowIndexes)
 }
 
-func parseRowSelection(input string, totalRows int) ([]int, int, error) {
+func parseRowSelection(input string, tail, totalRows int) ([]int, int, error) {
+	if tail > 0 {
+		start := totalRows - tail + 1
+		if start < 1 {
+			start = 1
+		}
+		values := []int{}
+		for v := start; v <= totalRows; v++ {
+			values = append(values, v)
+		}
+		if len(values) == 0 {
+			return nil, 0, errors.New("文件没有数据行")
+		}
+		return values, totalRows, nil
+	}
 	if input == "" {
 		return []int{1, 2, 3}, 3, nil
 	}
@@ -502,17 +1046,13 @@ func parseNumberRange(input string, maxValue int) ([]int, int, error) {
 		if item == "" {
 			continue
 		}
-		if strings.Contains(item, "-") {
-			parts := strings.SplitN(item, "-", 2)
-			if len(parts) != 2 {
-				return nil, 0, fmt.Errorf("无效范围: %s", item)
-			}
-			start, err := strconv.Atoi(strings.TrimSpace(parts[0]))
-			if err != nil || start <= 0 {
+		if startRaw, endRaw, ok := splitRangeItem(item); ok {
+			start, ok := resolveNumberBound(startRaw, maxValue)
+			if !ok {
 				return nil, 0, fmt.Errorf("无效范围: %s", item)
 			}
-			end, err := strconv.Atoi(strings.TrimSpace(parts[1]))
-			if err != nil || end <= 0 {
+			end, ok := resolveNumberBound(endRaw, maxValue)
+			if !ok {
 				return nil, 0, fmt.Errorf("无效范围: %s", item)
 			}
 			if start > end {
@@ -529,8 +1069,8 @@ func parseNumberRange(input string, maxValue int) ([]int, int, error) {
 			}
 			continue
 		}
-		value, err := strconv.Atoi(item)
-		if err != nil || value <= 0 {
+		value, ok := resolveNumberBound(item, maxValue)
+		if !ok {
 			return nil, 0, fmt.Errorf("无效范围: %s", item)
 		}
 		if value > maxRequested {
@@ -567,13 +1107,12 @@ func parseColumnRange(input string, maxValue int) ([]int, int, error) {
 		if item == "" {
 			continue
 		}
-		if strings.Contains(item, "-") {
-			parts := strings.SplitN(item, "-", 2)
-			start, ok := parseColumnIndex(parts[0])
+		if startRaw, endRaw, ok := splitRangeItem(item); ok {
+			start, ok := resolveColumnBound(startRaw, maxValue)
 			if !ok {
 				return nil, 0, fmt.Errorf("无效范围: %s", item)
 			}
-			end, ok := parseColumnIndex(parts[1])
+			end, ok := resolveColumnBound(endRaw, maxValue)
 			if !ok {
 				return nil, 0, fmt.Errorf("无效范围: %s", item)
 			}
@@ -591,7 +1130,7 @@ func parseColumnRange(input string, maxValue int) ([]int, int, error) {
 			}
 			continue
 		}
-		value, ok := parseColumnIndex(item)
+		value, ok := resolveColumnBound(item, maxValue)
 		if !ok {
 			return nil, 0, fmt.Errorf("无效范围: %s", item)
 		}
@@ -619,6 +1158,47 @@ func parseColumnRange(input string, maxValue int) ([]int, int, error) {
 	return filtered, maxRequested, nil
 }
 
+// splitRangeItem splits "x-y" where either bound may itself be negative, so
+// "-5--1" yields "-5" and "-1" while "-3" stays a single value.
+func splitRangeItem(item string) (string, string, bool) {
+	offset := 0
+	if strings.HasPrefix(item, "-") {
+		offset = 1
+	}
+	idx := strings.Index(item[offset:], "-")
+	if idx < 0 {
+		return item, "", false
+	}
+	return item[:offset+idx], item[offset+idx+1:], true
+}
+
+func isEndKeyword(raw string) bool {
+	return strings.EqualFold(strings.TrimSpace(raw), "end")
+}
+
+func resolveNumberBound(raw string, maxValue int) (int, bool) {
+	raw = strings.TrimSpace(raw)
+	if isEndKeyword(raw) {
+		return maxValue, maxValue > 0
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value == 0 {
+		return 0, false
+	}
+	if value < 0 {
+		value = maxValue + value + 1
+	}
+	return value, value > 0
+}
+
+func resolveColumnBound(raw string, maxValue int) (int, bool) {
+	raw = strings.TrimSpace(raw)
+	if isEndKeyword(raw) || strings.HasPrefix(raw, "-") {
+		return resolveNumberBound(raw, maxValue)
+	}
+	return parseColumnIndex(raw)
+}
+
 func parseColumnIndex(raw string) (int, bool) {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
@@ -644,6 +1224,25 @@ func parseColumnIndex(raw string) (int, bool) {
 	return value, true
 }
 
+func resolveColumn(file *excelize.File, sheet, spec string, headerRows, totalCols int) (int, error) {
+	spec = strings.TrimSpace(spec)
+	if headerRows > 0 {
+		for col := 1; col <= totalCols; col++ {
+			header, err := cellValue(file, sheet, headerRows, col)
+			if err != nil {
+				return 0, err
+			}
+			if strings.TrimSpace(header) == spec {
+				return col, nil
+			}
+		}
+	}
+	if col, ok := parseColumnIndex(spec); ok {
+		return col, nil
+	}
+	return 0, fmt.Errorf("找不到列: %s", spec)
+}
+
 func isNumeric(value string) bool {
 	value = strings.TrimSpace(value)
 	if value == "" {
@@ -670,26 +1269,14 @@ func numberToColumn(value int) string {
 	return result
 }
 
-func readRow(file *excelize.File, sheet string, rowIndex int, maxCols int) ([]string, error) {
-	row := make([]string, maxCols)
-	for col := 1; col <= maxCols; col++ {
-		value, err := cellValue(file, sheet, rowIndex, col)
+func readColumn(file *excelize.File, sheet string, colIndex int, rowIndexes []int) ([]string, error) {
+	col := make([]string, len(rowIndexes))
+	for i, row := range rowIndexes {
+		value, err := displayValue(file, sheet, row, colIndex)
 		if err != nil {
 			return nil, err
 		}
-		row[col-1] = value
-	}
-	return row, nil
-}
-
-func readColumn(file *excelize.File, sheet string, colIndex int, maxRows int) ([]string, error) {
-	col := make([]string, maxRows)
-	for row := 1; row <= maxRows; row++ {
-		value, err := cellValue(file, sheet, row, colIndex)
-		if err != nil {
-			return nil, err
-		}
-		col[row-1] = value
+		col[i] = value
 	}
 	return col, nil
 }
@@ -699,7 +1286,21 @@ func cellValue(file *excelize.File, sheet string, row, col int) (string, error)
 	if err != nil {
 		return "", err
 	}
-	value, err := file.GetCellValue(sheet, cell)
+	if display.merged != "fill" {
+		if _, covered := isMergedCovered(file, sheet, row, col); covered {
+			return "", nil
+		}
+	}
+	readOpts := excelize.Options{RawCellValue: display.valueMode == "raw"}
+	if display.calc {
+		if value, ok := calculatedValue(file, sheet, cell, readOpts); ok {
+			return value, nil
+		}
+	}
+	if value, ok := dateValue(file, sheet, cell); ok {
+		return value, nil
+	}
+	value, err := file.GetCellValue(sheet, cell, readOpts)
 	if err != nil {
 		return "", err
 	}
@@ -722,51 +1323,75 @@ func matchValue(value, keyword, mode string) bool {
 }
 
 func printSize(rows, cols int) {
-	fmt.Printf("Rows:%d,Cols:%d\n", rows, cols)
+	printLine("Rows:%d,Cols:%d", rows, cols)
+}
+
+func printGridData(file *excelize.File, sheet string, rows [][]string, rowIndexes, colIndexes []int) {
+	printNamedGridData(file, sheet, nil, rows, rowIndexes, colIndexes)
 }
 
-func printRowData(rows [][]string, rowIndexes []int, maxCols int) {
-	headers := make([]string, maxCols+1)
+// printNamedGridData heads the columns with names, such as the header row of
+// a --table, instead of column letters; nil names fall back to the letters.
+func printNamedGridData(file *excelize.File, sheet string, names []string, rows [][]string, rowIndexes, colIndexes []int) {
+	headers := make([]string, len(colIndexes)+1)
 	headers[0] = ""
-	for i := 1; i <= maxCols; i++ {
-		headers[i] = numberToColumn(i)
+	for i, col := range colIndexes {
+		if names != nil {
+			headers[i+1] = markColumn(file, sheet, col, names[i])
+		} else {
+			headers[i+1] = columnLabel(file, sheet, col)
+		}
+	}
+	if !printCSVRow(headers) {
+		printRowsTruncated(0, len(rows))
+		return
 	}
-	printCSVRow(headers)
 	for i, row := range rows {
-		line := make([]string, 0, maxCols+1)
-		line = append(line, strconv.Itoa(rowIndexes[i]))
-		line = append(line, row...)
-		printCSVRow(line)
+		line := make([]string, 0, len(colIndexes)+1)
+		line = append(line, rowLabel(file, sheet, rowIndexes[i]))
+		line = append(line, truncateCells(row)...)
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(rows))
+			return
+		}
 	}
 }
 
-func printColumnData(columns [][]string, colIndexes []int, maxRows int) {
+func printColumnData(file *excelize.File, sheet string, columns [][]string, colIndexes, rowIndexes []int) {
 	headers := make([]string, len(colIndexes)+1)
 	headers[0] = ""
 	for i, col := range colIndexes {
-		headers[i+1] = numberToColumn(col)
+		headers[i+1] = columnLabel(file, sheet, col)
 	}
-	printCSVRow(headers)
-	for row := 1; row <= maxRows; row++ {
+	if !printCSVRow(headers) {
+		printColumnsTruncated(0, len(rowIndexes))
+		return
+	}
+	for i, row := range rowIndexes {
 		line := make([]string, 0, len(colIndexes)+1)
-		line = append(line, strconv.Itoa(row))
+		line = append(line, rowLabel(file, sheet, row))
 		for _, col := range columns {
-			line = append(line, col[row-1])
+			line = append(line, truncateCell(col[i]))
+		}
+		if !printCSVRow(line) {
+			printColumnsTruncated(i, len(rowIndexes))
+			return
 		}
-		printCSVRow(line)
 	}
 }
 
 func printSearchResultHeader(count int) {
-	fmt.Printf("搜索结果: 找到 %d 个匹配\n", count)
+	if !printLine("搜索结果: 找到 %d 个匹配", count) {
+		printOutputTruncated()
+	}
 }
 
-func printCSVRow(values []string) {
+func printCSVRow(values []string) bool {
 	escaped := make([]string, len(values))
 	for i, value := range values {
 		escaped[i] = escapeCSV(value)
 	}
-	fmt.Println(strings.Join(escaped, ","))
+	return printLine("%s", strings.Join(escaped, ","))
 }
 
 func escapeCSV(value string) string {
@@ -800,30 +1425,122 @@ func printHelp() {
 	fmt.Println("  xlsx_viewer --path <xlsx文件路径> <操作类型> [参数]")
 	fmt.Println()
 	fmt.Println("必填参数:")
-	fmt.Println("  --path <文件路径>    指定 xlsx 文件的绝对路径")
+	fmt.Println("  --path <文件路径>    指定 Excel 文件的绝对路径, 支持 .xlsx, .xlsm, .xltx, .xltm, 旧版 .xls 和 OpenDocument .ods (按文件内容识别实际类型), 以及 .csv/.tsv 文本表格")
+	fmt.Println()
+	fmt.Println("可选参数:")
+	fmt.Println("  --password <密码>    打开加密的 xlsx 文件(也可通过环境变量 XLSX_VIEWER_PASSWORD 提供), 同时用于 --id-files 中的文件")
+	fmt.Println("  --delimiter <字符>   .csv/.tsv 的分隔符(默认 .csv 为逗号, .tsv 为制表符), 制表符可写作 \\t 或 tab")
+	fmt.Println("  --encoding <编码>    .csv/.tsv 的编码: utf-8(默认), gbk, utf-16; 文件带 BOM 时按 BOM 识别")
 	fmt.Println()
 	fmt.Println("操作类型 (必选其一):")
-	fmt.Println("  --size                          显示文件行列数")
+	fmt.Println("  --info                          显示工作簿信息: 创建者、最后修改者、创建/修改时间、应用版本, 各 sheet 行列数和公式数, 名称/表格数量, 是否含宏和外部链接")
+	fmt.Println("  --size                          显示文件行列数, 以及隐藏行列(HiddenRows/HiddenCols)和自动筛选区域与条件(AutoFilter/Filter)")
 	fmt.Println("  --rows [x] [y]                  显示第x到第y行(默认1-3行), 可选 --max-cols m 限制每行最多m列(默认50)")
 	fmt.Println("  --cols [x] [y]                  显示第x到第y列(默认1-3列), 可选 --max-rows m 限制每列最多m行(默认50)")
 	fmt.Println("  --search-col <列索引> <关键词>   在指定列搜索关键词")
 	fmt.Println("  --search-row <行索引> <关键词>   在指定行搜索关键词")
+	fmt.Println("  --rows <行范围> --cols <列范围>  同时指定时只读取所选行与所选列的交叉区域")
+	fmt.Println("  --tail <n>                      显示最后n行数据, 可配合 --cols 选择列")
+	fmt.Println("  --profile [列范围]              统计各列推断类型、非空数、唯一值、数值范围、高频值等(默认全部列)")
+	fmt.Println("  --id-gaps <列>                  检查整数ID列的重复、空缺和空闲区间, 列可用列标号或表头名")
+	fmt.Println("  --duplicates                    按 --key 指定列(默认整行)查找重复行, 每组输出行号和整行数据")
+	fmt.Println("  --range <区域>                  按 A1 写法读取矩形区域: B2:F20, 整列 C:E, 整行 5:9, 多个区域用逗号分隔; 也可用 Sheet2!A1:C5 或定义的名称")
+	fmt.Println("  --list-formulas                 列出所有公式单元格的地址、公式和缓存值(配合 --calc 同时列出重算值)")
+	fmt.Println("  --stale-formulas                重算所有公式, 列出重算值与缓存值不一致的单元格")
+	fmt.Println("  --list-merged                   列出所有合并单元格区域及其值")
+	fmt.Println("  --list-comments                 列出所有批注的单元格地址、作者、内容和单元格值")
+	fmt.Println("  --validations                   列出数据验证规则(区域、类型、下拉列表来源、最小/最大值)")
+	fmt.Println("  --check-validations             按数据验证规则检查数据, 列出不符合的单元格(跳过 --header-rows 表头行)")
+	fmt.Println("  --list-links                    列出所有超链接的单元格、类型(内部 Sheet!A1 / 外部)、目标和单元格值")
+	fmt.Println("  --list-pictures                 列出所有图片的锚定单元格、格式、字节数、像素尺寸和插入方式")
+	fmt.Println("  --extract-pictures <目录>       将图片导出到目录, 按 sheet名_单元格.扩展名 命名")
+	fmt.Println("  --list-names                    列出工作簿中定义的名称及其引用区域")
+	fmt.Println("  --list-tables                   列出所有 Excel 表格(ListObject)的表名、所在 sheet、区域和列名")
+	fmt.Println("  --table <表名>                  读取表格的数据行, 以表头行作为列名; 可配合 --cols/--where 按列名选择和筛选")
 	fmt.Println()
 	fmt.Println("搜索参数 (用于--search-col和--search-row):")
 	fmt.Println("  --mode <模式>        搜索模式: fuzzy(默认,模糊), exact(精确), regex(正则)")
-	fmt.Println("  --limit <数量>       返回最多条数(默认10)")
+	fmt.Println("  --limit <数量>       每页返回最多条数(默认10)")
+	fmt.Println()
+	fmt.Println("分页参数 (用于--search-col, --search-row, --rows, --cols):")
+	fmt.Println("  --offset <n>         跳过前n个结果/行/列")
+	fmt.Println("  --cursor <令牌>      从上一页末尾输出的令牌继续读取下一页")
+	fmt.Println("  --limit <数量>       --rows/--cols 指定时按此数量分页, 搜索时即每页条数")
+	fmt.Println("  结果未读完时末尾输出: 下一页: --cursor <令牌> (或 --offset n)")
+	fmt.Println()
+	fmt.Println("输出控制 (用于所有表格输出):")
+	fmt.Println("  --max-cell-chars <n>     单元格超过n个字符时截断, 并标注原长度, 如 \"前n个字…(共1234字)\"")
+	fmt.Println("  --max-output-bytes <n>   输出超过n字节时停止, --rows/--cols/搜索/--table 提示用 --offset 继续")
+	fmt.Println("  --max-output-tokens <n>  按估算token数(约4个ASCII字符或1个中文字符为1个token)限制输出")
+	fmt.Println()
+	fmt.Println("单元格信息 (用于所有表格输出):")
+	fmt.Println("  --formulas           在含公式的单元格值后附加公式, 如 \"2002 {=A2*2}\"")
+	fmt.Println("  --comments           在带批注的单元格值后附加批注作者和内容, 如 \"ID {批注(策划):主键ID}\"")
+	fmt.Println("  --hyperlinks         在带超链接的单元格值后附加链接目标, 如 \"攻击提升 {链接:Sheet2!A1}\"")
+	fmt.Println("  --calc               重新计算公式单元格, 用计算结果代替文件中缓存的值(搜索也使用计算结果)")
+	fmt.Println("  --values <模式>      formatted(默认,按数字格式显示), raw(存储的原始值), both(显示值后附加原值、数字格式和数据类型)")
+	fmt.Println("  --merged <模式>      合并单元格: fill(默认,每个被合并的单元格都显示合并值, 搜索也能命中), blank(只有左上角有值), mark(左上角标注区域, 其余标注所属区域)")
+	fmt.Println("  --rich-text [模式]   富文本单元格按格式片段输出: unity(默认, 如 \"伤害提升<color=#FF0000><b>15%</b></color>\"), json(片段数组, 含字体属性)")
+	fmt.Println("  --date-format <格式> 日期单元格的显示格式: iso(默认, 如2026-10-01或2026-10-01T08:30:00), excel(按文件中的格式), 或自定义如 yyyy/MM/dd HH:mm")
+	fmt.Println()
+	fmt.Println("隐藏行列 (用于所有表格输出):")
+	fmt.Println("  --skip-hidden        跳过隐藏的行和列(含折叠分组), 搜索、概况和重复检查也忽略隐藏行")
+	fmt.Println("  --mark-hidden        在行号/列标号后标注隐藏状态和分组层级, 如 \"4[隐藏]\", \"D[隐藏,层级1]\"")
+	fmt.Println()
+	fmt.Println("筛选参数 (用于--rows, --tail, --search-col, --table):")
+	fmt.Println("  --where <条件>       只保留满足条件的行, 如 \"StartTime >= 2026-10-01\", 列可用列标号或表头名")
+	fmt.Println("                       比较符: = != > >= < <=; 日期按时间比较, 数字按数值比较, 其余按文本比较; 可多次指定(同时满足)")
+	fmt.Println()
+	fmt.Println("概况参数 (用于--profile):")
+	fmt.Println("  --header-rows <n>    表头行数(默认1), 最后一行表头作为列名, 之后为数据行")
+	fmt.Println("  --top <n>            每列显示的高频值个数(默认5)")
+	fmt.Println()
+	fmt.Println("ID参数 (用于--id-gaps):")
+	fmt.Println("  --id-range <x-y>     只在指定ID区间内统计(默认为已用ID的最小到最大值)")
+	fmt.Println("  --next-id <n>        分配n个可用ID: 先取区间内最大已用ID之后的号, 不足时回填空缺")
+	fmt.Println("  --id-files <路径,..> 同一ID空间的其他表, 按相同的列一起检查")
+	fmt.Println()
+	fmt.Println("重复参数 (用于--duplicates):")
+	fmt.Println("  --key <列,..>        作为重复键的列, 可用列标号或表头名, 多列用逗号分隔")
+	fmt.Println("  --limit <数量>       最多显示的重复组数(默认10)")
 	fmt.Println()
 	fmt.Println("其他:")
 	fmt.Println("  --help               显示此帮助信息")
 	fmt.Println()
 	fmt.Println("行列索引说明:")
 	fmt.Println("  行列索引从 1 开始(如第1行、第1列)")
+	fmt.Println("  负数索引从数据末尾倒数(-1 为最后一行/列), end 表示最后一行/列, 如 --rows -5--1, --rows 100-end")
 	fmt.Println()
 	fmt.Println("示例:")
 	fmt.Println("  xlsx_viewer --path data.xlsx --size")
+	fmt.Println("  xlsx_viewer --path data.xlsx --info")
+	fmt.Println("  xlsx_viewer --path secret.xlsx --password 123456 --rows 1-5")
+	fmt.Println("  xlsx_viewer --path runtime/buff.csv --encoding gbk --search-col B 攻击")
+	fmt.Println("  xlsx_viewer --path data.ods --range 第二页!A1:F20")
 	fmt.Println("  xlsx_viewer --path data.xlsx --rows 1 5 --max-cols 20")
 	fmt.Println("  xlsx_viewer --path data.xlsx --rows 10")
 	fmt.Println("  xlsx_viewer --path data.xlsx --cols 1 3 --max-rows 100")
 	fmt.Println("  xlsx_viewer --path data.xlsx --search-col 2 \"测试\" --mode exact --limit 5")
 	fmt.Println("  xlsx_viewer --path data.xlsx --search-row 1 \"error\" --mode regex --limit 20")
+	fmt.Println("  xlsx_viewer --path data.xlsx --profile A-E --header-rows 3 --top 3")
+	fmt.Println("  xlsx_viewer --path data.xlsx --id-gaps ID --id-range 1000-1999 --next-id 5")
+	fmt.Println("  xlsx_viewer --path data.xlsx --duplicates --key A,Name")
+	fmt.Println("  xlsx_viewer --path data.xlsx --range C10:H40,A:A")
+	fmt.Println("  xlsx_viewer --path data.xlsx --range BuffTable")
+	fmt.Println("  xlsx_viewer --path data.xlsx --table Buffs --cols BuffID,BuffName --where \"Level >= 3\"")
+	fmt.Println("  xlsx_viewer --path data.xlsx --rows 10-40 --cols C-H")
+	fmt.Println("  xlsx_viewer --path data.xlsx --tail 5")
+	fmt.Println("  xlsx_viewer --path data.xlsx --search-col B \"攻击\" --limit 20 --offset 20")
+	fmt.Println("  xlsx_viewer --path data.xlsx --rows 1-200 --max-cell-chars 80 --max-output-tokens 4000")
+	fmt.Println("  xlsx_viewer --path data.xlsx --rows 2-5 --formulas")
+	fmt.Println("  xlsx_viewer --path data.xlsx --stale-formulas")
+	fmt.Println("  xlsx_viewer --path data.xlsx --rows 2 --values both")
+	fmt.Println("  xlsx_viewer --path data.xlsx --rows 1-20 --skip-hidden")
+	fmt.Println("  xlsx_viewer --path data.xlsx --cols A --merged mark")
+	fmt.Println("  xlsx_viewer --path data.xlsx --rows 1 --comments")
+	fmt.Println("  xlsx_viewer --path data.xlsx --search-col C \"提升\" --rich-text")
+	fmt.Println("  xlsx_viewer --path data.xlsx --check-validations")
+	fmt.Println("  xlsx_viewer --path data.xlsx --list-links")
+	fmt.Println("  xlsx_viewer --path data.xlsx --extract-pictures ./icons")
+	fmt.Println("  xlsx_viewer --path data.xlsx --rows 2-end --where \"StartTime >= 2026-10-01\" --date-format yyyy/MM/dd")
 }
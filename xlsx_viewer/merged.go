@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type mergedArea struct {
+	ref      string
+	anchor   string
+	startRow int
+	startCol int
+	endRow   int
+	endCol   int
+}
+
+var mergedCache = map[string][]mergedArea{}
+
+func parseMergedMode(value string) (string, error) {
+	value = strings.ToLower(value)
+	switch value {
+	case "fill", "blank", "mark":
+		return value, nil
+	default:
+		return "", fmt.Errorf("--merged 只能是 fill, blank, mark")
+	}
+}
+
+func mergedAreas(file *excelize.File, sheet string) ([]mergedArea, error) {
+	key := file.Path + "!" + sheet
+	if areas, ok := mergedCache[key]; ok {
+		return areas, nil
+	}
+	cells, err := file.GetMergeCells(sheet, true)
+	if err != nil {
+		return nil, err
+	}
+	areas := make([]mergedArea, 0, len(cells))
+	for _, cell := range cells {
+		ref := cell[0]
+		parts := strings.Split(ref, ":")
+		startCol, startRow, err := excelize.CellNameToCoordinates(parts[0])
+		if err != nil {
+			continue
+		}
+		endCol, endRow := startCol, startRow
+		if len(parts) == 2 {
+			if endCol, endRow, err = excelize.CellNameToCoordinates(parts[1]); err != nil {
+				continue
+			}
+		}
+		areas = append(areas, mergedArea{
+			ref:      ref,
+			anchor:   parts[0],
+			startRow: startRow,
+			startCol: startCol,
+			endRow:   endRow,
+			endCol:   endCol,
+		})
+	}
+	mergedCache[key] = areas
+	return areas, nil
+}
+
+func findMergedArea(file *excelize.File, sheet string, row, col int) (mergedArea, bool) {
+	areas, err := mergedAreas(file, sheet)
+	if err != nil {
+		return mergedArea{}, false
+	}
+	for _, area := range areas {
+		if row >= area.startRow && row <= area.endRow && col >= area.startCol && col <= area.endCol {
+			return area, true
+		}
+	}
+	return mergedArea{}, false
+}
+
+// isMergedCovered reports whether the cell lies inside a merged range without
+// being its top-left anchor, the only cell Excel actually stores a value in.
+func isMergedCovered(file *excelize.File, sheet string, row, col int) (mergedArea, bool) {
+	area, ok := findMergedArea(file, sheet, row, col)
+	if !ok || (row == area.startRow && col == area.startCol) {
+		return area, false
+	}
+	return area, true
+}
+
+func handleListMerged(file *excelize.File, sheet string) {
+	areas, err := mergedAreas(file, sheet)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	if !printLine("合并单元格: 共 %d 个", len(areas)) {
+		printOutputTruncated()
+		return
+	}
+	if len(areas) == 0 {
+		return
+	}
+	printCSVRow([]string{"区域", "行数", "列数", "值"})
+	for i, area := range areas {
+		value, err := cellValue(file, sheet, area.startRow, area.startCol)
+		if err != nil {
+			exitWithError(err.Error())
+		}
+		line := []string{
+			area.ref,
+			fmt.Sprint(area.endRow - area.startRow + 1),
+			fmt.Sprint(area.endCol - area.startCol + 1),
+			truncateCell(value),
+		}
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(areas))
+			return
+		}
+	}
+}
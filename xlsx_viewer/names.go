@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// splitSheetRef splits "Sheet2!$A$1:$B$3" or "'My Sheet'!A1" into the sheet
+// and a plain upper-case reference; refs without a sheet use defaultSheet.
+func splitSheetRef(ref, defaultSheet string) (string, string) {
+	sheet := defaultSheet
+	if idx := strings.LastIndex(ref, "!"); idx >= 0 {
+		sheet = strings.ReplaceAll(strings.Trim(ref[:idx], "'"), "''", "'")
+		ref = ref[idx+1:]
+	}
+	return sheet, strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(ref), "$", ""))
+}
+
+// definedNameRef looks up a defined name, preferring one scoped to sheet over
+// a workbook-level one.
+func definedNameRef(file *excelize.File, sheet, name string) (string, bool) {
+	ref, found := "", false
+	for _, item := range file.GetDefinedName() {
+		if !strings.EqualFold(item.Name, name) {
+			continue
+		}
+		if strings.EqualFold(item.Scope, sheet) {
+			return strings.TrimPrefix(item.RefersTo, "="), true
+		}
+		if item.Scope == "" || item.Scope == "Workbook" {
+			ref, found = strings.TrimPrefix(item.RefersTo, "="), true
+		}
+	}
+	return ref, found
+}
+
+func findTable(file *excelize.File, name string) (string, excelize.Table, bool) {
+	for _, sheet := range file.GetSheetList() {
+		tables, err := file.GetTables(sheet)
+		if err != nil {
+			continue
+		}
+		for _, table := range tables {
+			if strings.EqualFold(table.Name, name) {
+				return sheet, table, true
+			}
+		}
+	}
+	return "", excelize.Table{}, false
+}
+
+func tableHasHeader(table excelize.Table) bool {
+	return table.ShowHeaderRow == nil || *table.ShowHeaderRow
+}
+
+func tableHeaders(file *excelize.File, sheet string, table excelize.Table, area cellArea) ([]string, error) {
+	headers := make([]string, 0, area.endCol-area.startCol+1)
+	for col := area.startCol; col <= area.endCol; col++ {
+		header := ""
+		if tableHasHeader(table) {
+			value, err := cellValue(file, sheet, area.startRow, col)
+			if err != nil {
+				return nil, err
+			}
+			header = value
+		}
+		if header == "" {
+			header = numberToColumn(col)
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+func handleListNames(file *excelize.File) {
+	names := file.GetDefinedName()
+	if !printLine("名称: 共 %d 个", len(names)) {
+		printOutputTruncated()
+		return
+	}
+	if len(names) == 0 {
+		return
+	}
+	printCSVRow([]string{"名称", "作用范围", "引用", "备注"})
+	for i, name := range names {
+		line := []string{name.Name, name.Scope, strings.TrimPrefix(name.RefersTo, "="), truncateCell(name.Comment)}
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(names))
+			return
+		}
+	}
+}
+
+func handleListTables(file *excelize.File) {
+	lines := [][]string{}
+	for _, sheet := range file.GetSheetList() {
+		tables, err := file.GetTables(sheet)
+		if err != nil {
+			continue
+		}
+		for _, table := range tables {
+			area, err := parseArea(strings.ToUpper(strings.ReplaceAll(table.Range, "$", "")), 0, 0)
+			if err != nil {
+				continue
+			}
+			headers, err := tableHeaders(file, sheet, table, area)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+			dataRows := area.endRow - area.startRow + 1
+			if tableHasHeader(table) {
+				dataRows--
+			}
+			lines = append(lines, []string{table.Name, sheet, table.Range, strings.Join(headers, "|"), strconv.Itoa(dataRows)})
+		}
+	}
+	if !printLine("表格: 共 %d 个", len(lines)) {
+		printOutputTruncated()
+		return
+	}
+	if len(lines) == 0 {
+		return
+	}
+	printCSVRow([]string{"表名", "sheet", "区域", "列名", "数据行数"})
+	for i, line := range lines {
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(lines))
+			return
+		}
+	}
+}
+
+// handleTable prints the data rows of an Excel table with its header row as
+// column names; --cols and --where may refer to those names.
+func handleTable(file *excelize.File, opts options) {
+	sheet, table, ok := findTable(file, opts.tableName)
+	if !ok {
+		exitWithError(fmt.Sprintf("找不到表格: %s", opts.tableName))
+	}
+	area, err := parseArea(strings.ToUpper(strings.ReplaceAll(table.Range, "$", "")), 0, 0)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	headers, err := tableHeaders(file, sheet, table, area)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	firstRow := area.startRow
+	if tableHasHeader(table) {
+		firstRow++
+	}
+
+	colIndexes := []int{}
+	if opts.colsRaw == "" {
+		for col := area.startCol; col <= area.endCol; col++ {
+			colIndexes = append(colIndexes, col)
+		}
+	} else {
+		for _, item := range strings.Split(opts.colsRaw, ",") {
+			if strings.TrimSpace(item) == "" {
+				continue
+			}
+			col, err := resolveTableColumn(headers, area, item)
+			if err != nil {
+				exitWithUsageError(err.Error())
+			}
+			colIndexes = append(colIndexes, col)
+		}
+	}
+	colIndexes = visibleCols(file, sheet, colIndexes)
+
+	rowIndexes := []int{}
+	for row := firstRow; row <= area.endRow; row++ {
+		rowIndexes = append(rowIndexes, row)
+	}
+	rowIndexes = visibleRows(file, sheet, rowIndexes)
+	rowIndexes = filterTableRows(file, sheet, rowIndexes, headers, area, opts)
+	pageSize := 0
+	if opts.limitSet {
+		pageSize = opts.limit
+	}
+	rowIndexes, pg := applyPage(rowIndexes, opts, pageSize)
+
+	if !printLine("表格: %s, sheet %s, 区域 %s", table.Name, sheet, table.Range) {
+		printOutputTruncated()
+		return
+	}
+	printPageInfo(pg, "行")
+	defer printPageFooter(pg, opts)
+	names := make([]string, len(colIndexes))
+	for i, col := range colIndexes {
+		names[i] = headers[col-area.startCol]
+	}
+	data := make([][]string, 0, len(rowIndexes))
+	for _, row := range rowIndexes {
+		values, err := readCells(file, sheet, row, colIndexes)
+		if err != nil {
+			exitWithError(err.Error())
+		}
+		data = append(data, values)
+	}
+	printNamedGridData(file, sheet, names, data, rowIndexes, colIndexes)
+}
+
+func resolveTableColumn(headers []string, area cellArea, spec string) (int, error) {
+	spec = strings.TrimSpace(spec)
+	for i, header := range headers {
+		if strings.EqualFold(header, spec) {
+			return area.startCol + i, nil
+		}
+	}
+	if col, ok := parseColumnIndex(spec); ok && !isNumeric(spec) && col >= area.startCol && col <= area.endCol {
+		return col, nil
+	}
+	return 0, fmt.Errorf("表格中没有列: %s", spec)
+}
+
+func filterTableRows(file *excelize.File, sheet string, rowIndexes []int, headers []string, area cellArea, opts options) []int {
+	if len(opts.where) == 0 {
+		return rowIndexes
+	}
+	clauses := make([]whereClause, len(opts.where))
+	for i, clause := range opts.where {
+		col, err := resolveTableColumn(headers, area, clause.column)
+		if err != nil {
+			exitWithUsageError(err.Error())
+		}
+		clause.col = col
+		clauses[i] = clause
+	}
+	return matchRows(file, sheet, rowIndexes, clauses)
+}
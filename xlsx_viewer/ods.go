@@ -0,0 +1,365 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	odsMimeType    = "application/vnd.oasis.opendocument.spreadsheet"
+	odsOfficeSpace = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	odsTableSpace  = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	odsTextSpace   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	odsStyleSpace  = "urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+)
+
+var odsDurationPattern = regexp.MustCompile(`^-?P(?:(\d+)D)?T?(?:(\d+)H)?(?:(\d+)M)?(?:([\d.]+)S)?$`)
+
+type odsReader struct{}
+
+// odsSheetState tracks the position inside one table:table while its rows
+// are streamed; repeated rows and columns advance the cursor without being
+// expanded unless they hold content.
+type odsSheetState struct {
+	sheet      sourceSheet
+	row        int
+	col        int
+	lastRow    int
+	lastCol    int
+	hiddenRows [][2]int
+	hiddenCols [][2]int
+	rowCells   []sourceCell
+	rowRepeat  int
+	rowHidden  bool
+}
+
+// isODSFile checks the mimetype entry OpenDocument packages start with.
+func isODSFile(path string) bool {
+	if !bytes.HasPrefix(readFileHead(path, len(zipSignature)), zipSignature) {
+		return false
+	}
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = archive.Close()
+	}()
+	data, err := readZipEntry(&archive.Reader, "mimetype")
+	return err == nil && strings.TrimSpace(string(data)) == odsMimeType
+}
+
+func readZipEntry(archive *zip.Reader, name string) ([]byte, error) {
+	for _, entry := range archive.File {
+		if entry.Name != name {
+			continue
+		}
+		reader, err := entry.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = reader.Close()
+		}()
+		return io.ReadAll(reader)
+	}
+	return nil, fmt.Errorf("缺少 %s", name)
+}
+
+func (odsReader) read(path string) (*sourceWorkbook, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("文件已损坏: %s", err.Error())
+	}
+	defer func() {
+		_ = archive.Close()
+	}()
+	content, err := readZipEntry(&archive.Reader, "content.xml")
+	if err != nil {
+		return nil, err
+	}
+	book := &sourceWorkbook{kind: workbookKind{extension: ".ods", description: "OpenDocument 电子表格"}}
+	hiddenStyles := map[string]bool{}
+	styleName := ""
+	var state *odsSheetState
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("content.xml 解析失败: %s", err.Error())
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch {
+			case element.Name.Space == odsStyleSpace && element.Name.Local == "style":
+				styleName = odsAttr(element, odsStyleSpace, "name")
+			case element.Name.Space == odsStyleSpace && element.Name.Local == "table-properties":
+				if odsAttr(element, odsTableSpace, "display") == "false" {
+					hiddenStyles[styleName] = true
+				}
+			case element.Name.Space != odsTableSpace:
+			case element.Name.Local == "table":
+				name := odsAttr(element, odsTableSpace, "name")
+				state = &odsSheetState{sheet: sourceSheet{name: name, hidden: hiddenStyles[odsAttr(element, odsTableSpace, "style-name")]}}
+			case state == nil:
+			case element.Name.Local == "table-column":
+				repeat := odsRepeat(element, "number-columns-repeated")
+				if odsHidden(element) {
+					state.hiddenCols = append(state.hiddenCols, [2]int{state.col + 1, state.col + repeat})
+				}
+				state.col += repeat
+			case element.Name.Local == "table-row":
+				state.rowRepeat = odsRepeat(element, "number-rows-repeated")
+				state.rowHidden = odsHidden(element)
+				state.rowCells = nil
+				state.col = 0
+			case element.Name.Local == "table-cell" || element.Name.Local == "covered-table-cell":
+				if err := state.readCell(decoder, element); err != nil {
+					return nil, fmt.Errorf("%s: %s", state.sheet.name, err.Error())
+				}
+			}
+		case xml.EndElement:
+			if element.Name.Space != odsTableSpace || state == nil {
+				continue
+			}
+			switch element.Name.Local {
+			case "table-row":
+				state.endRow()
+			case "table":
+				book.sheets = append(book.sheets, state.finish())
+				state = nil
+			}
+		}
+	}
+	return book, nil
+}
+
+func odsAttr(element xml.StartElement, space, local string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Space == space && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func odsRepeat(element xml.StartElement, local string) int {
+	repeat, err := strconv.Atoi(odsAttr(element, odsTableSpace, local))
+	if err != nil || repeat < 1 {
+		return 1
+	}
+	return repeat
+}
+
+func odsHidden(element xml.StartElement) bool {
+	visibility := odsAttr(element, odsTableSpace, "visibility")
+	return visibility == "collapse" || visibility == "filter"
+}
+
+func (s *odsSheetState) readCell(decoder *xml.Decoder, element xml.StartElement) error {
+	repeat := odsRepeat(element, "number-columns-repeated")
+	text, err := odsCellText(decoder)
+	if err != nil {
+		return err
+	}
+	cell, ok := odsCellValue(element, text)
+	if ok {
+		for i := 0; i < repeat && s.col+i < excelize.MaxColumns; i++ {
+			cell.col = s.col + i + 1
+			s.rowCells = append(s.rowCells, cell)
+		}
+	}
+	colSpan := odsRepeat(element, "number-columns-spanned")
+	rowSpan := odsRepeat(element, "number-rows-spanned")
+	if colSpan > 1 || rowSpan > 1 {
+		start, _ := excelize.CoordinatesToCellName(s.col+1, s.row+1)
+		end, _ := excelize.CoordinatesToCellName(s.col+colSpan, s.row+rowSpan)
+		s.sheet.merged = append(s.sheet.merged, start+":"+end)
+	}
+	s.col += repeat
+	return nil
+}
+
+// odsCellText collects the paragraphs of a cell up to its end element;
+// annotations are skipped and text:s, text:tab and text:line-break are
+// expanded.
+func odsCellText(decoder *xml.Decoder) (string, error) {
+	var text strings.Builder
+	paragraphs := 0
+	for depth := 1; depth > 0; {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			if element.Name.Space == odsOfficeSpace && element.Name.Local == "annotation" {
+				if err := decoder.Skip(); err != nil {
+					return "", err
+				}
+				continue
+			}
+			depth++
+			if element.Name.Space != odsTextSpace {
+				continue
+			}
+			switch element.Name.Local {
+			case "p", "h":
+				if paragraphs > 0 {
+					text.WriteString("\n")
+				}
+				paragraphs++
+			case "s":
+				count, err := strconv.Atoi(odsAttr(element, odsTextSpace, "c"))
+				if err != nil || count < 1 {
+					count = 1
+				}
+				text.WriteString(strings.Repeat(" ", count))
+			case "tab":
+				text.WriteString("\t")
+			case "line-break":
+				text.WriteString("\n")
+			}
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth > 1 {
+				text.Write(element)
+			}
+		}
+	}
+	return text.String(), nil
+}
+
+// odsCellValue converts a cell by its office:value-type. Numbers, dates and
+// times keep their typed value so --values raw and date formatting behave as
+// they do for xlsx; the displayed text is only used to pick a percentage
+// format.
+func odsCellValue(element xml.StartElement, text string) (sourceCell, bool) {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == "value-type" && attr.Name.Space != odsOfficeSpace && attr.Value == "error" {
+			return sourceCell{kind: sourceError, text: text}, true
+		}
+	}
+	switch odsAttr(element, odsOfficeSpace, "value-type") {
+	case "float", "currency":
+		if number, err := strconv.ParseFloat(odsAttr(element, odsOfficeSpace, "value"), 64); err == nil {
+			return sourceCell{kind: sourceNumber, number: number}, true
+		}
+	case "percentage":
+		if number, err := strconv.ParseFloat(odsAttr(element, odsOfficeSpace, "value"), 64); err == nil {
+			return sourceCell{kind: sourceNumber, number: number, format: percentFormat(text)}, true
+		}
+	case "date":
+		if cell, ok := odsDateCell(odsAttr(element, odsOfficeSpace, "date-value")); ok {
+			return cell, true
+		}
+	case "time":
+		if days, ok := odsDuration(odsAttr(element, odsOfficeSpace, "time-value")); ok {
+			return sourceCell{kind: sourceNumber, number: days, format: "hh:mm:ss"}, true
+		}
+	case "boolean":
+		return sourceCell{kind: sourceBool, text: boolText(odsAttr(element, odsOfficeSpace, "boolean-value") == "true")}, true
+	}
+	if text == "" {
+		return sourceCell{}, false
+	}
+	return sourceCell{kind: sourceText, text: text}, true
+}
+
+func percentFormat(text string) string {
+	text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "%"))
+	if idx := strings.IndexAny(text, ".,"); idx >= 0 && idx < len(text)-1 {
+		return "0." + strings.Repeat("0", len(text)-idx-1) + "%"
+	}
+	return "0%"
+}
+
+func odsDateCell(value string) (sourceCell, bool) {
+	layouts := []struct {
+		layout string
+		format string
+	}{
+		{"2006-01-02", "yyyy-mm-dd"},
+		{"2006-01-02T15:04:05", "yyyy-mm-dd hh:mm:ss"},
+		{"2006-01-02T15:04:05.999999999", "yyyy-mm-dd hh:mm:ss"},
+	}
+	for _, item := range layouts {
+		if t, err := time.Parse(item.layout, value); err == nil {
+			epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+			return sourceCell{kind: sourceNumber, number: t.Sub(epoch).Hours() / 24, format: item.format}, true
+		}
+	}
+	return sourceCell{}, false
+}
+
+// odsDuration converts an ISO 8601 duration such as PT10H30M00S to a
+// fraction of a day.
+func odsDuration(value string) (float64, bool) {
+	match := odsDurationPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, false
+	}
+	total := 0.0
+	for i, unit := range []float64{86400, 3600, 60, 1} {
+		if match[i+1] == "" {
+			continue
+		}
+		number, err := strconv.ParseFloat(match[i+1], 64)
+		if err != nil {
+			return 0, false
+		}
+		total += number * unit
+	}
+	if strings.HasPrefix(value, "-") {
+		total = -total
+	}
+	return math.Round(total) / 86400, true
+}
+
+func (s *odsSheetState) endRow() {
+	if len(s.rowCells) > 0 {
+		for i := 0; i < s.rowRepeat && s.row+i < excelize.TotalRows; i++ {
+			for _, cell := range s.rowCells {
+				cell.row = s.row + i + 1
+				s.sheet.cells = append(s.sheet.cells, cell)
+				s.lastCol = max(s.lastCol, cell.col)
+			}
+		}
+		s.lastRow = min(s.row+s.rowRepeat, excelize.TotalRows)
+	}
+	if s.rowHidden {
+		s.hiddenRows = append(s.hiddenRows, [2]int{s.row + 1, s.row + s.rowRepeat})
+	}
+	s.row += s.rowRepeat
+}
+
+// finish clips hidden ranges to the used area, since LibreOffice writes the
+// trailing empty rows and columns of a sheet as one huge repeated element.
+func (s *odsSheetState) finish() sourceSheet {
+	for _, span := range s.hiddenRows {
+		for row := span[0]; row <= min(span[1], s.lastRow); row++ {
+			s.sheet.hiddenRows = append(s.sheet.hiddenRows, row)
+		}
+	}
+	for _, span := range s.hiddenCols {
+		for col := span[0]; col <= min(span[1], s.lastCol); col++ {
+			s.sheet.hiddenCols = append(s.sheet.hiddenCols, col)
+		}
+	}
+	return s.sheet
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func TestODSDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  float64
+		ok    bool
+	}{
+		{"PT10H30M00S", 0.4375, true},
+		{"PT12H", 0.5, true},
+		{"P1DT06H00M00S", 1.25, true},
+		{"-PT06H00M00S", -0.25, true},
+		{"PT00H00M01.6S", 2.0 / 86400, true},
+		{"10:30:00", 0, false},
+		{"PT10X", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := odsDuration(tt.value)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("odsDuration(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPercentFormat(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"25%", "0%"},
+		{"12.5%", "0.0%"},
+		{"12,50 %", "0.00%"},
+		{"-3.125%", "0.000%"},
+		{"7", "0%"},
+	}
+	for _, tt := range tests {
+		if got := percentFormat(tt.text); got != tt.want {
+			t.Errorf("percentFormat(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+const odsTestContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" office:version="1.2">
+<office:body><office:spreadsheet>
+<table:table table:name="Buffs">
+<table:table-column table:number-columns-repeated="3"/>
+<table:table-column table:visibility="collapse"/>
+<table:table-row>
+<table:table-cell office:value-type="string"><text:p>ID</text:p></table:table-cell>
+<table:table-cell office:value-type="string"><text:p>Name</text:p></table:table-cell>
+<table:table-cell office:value-type="string"><text:p>Rate</text:p></table:table-cell>
+<table:table-cell office:value-type="string"><text:p>Time</text:p></table:table-cell>
+</table:table-row>
+<table:table-row>
+<table:table-cell office:value-type="float" office:value="1001"><text:p>1001</text:p></table:table-cell>
+<table:table-cell office:value-type="string"><text:p>攻击<text:s text:c="2"/>提升</text:p></table:table-cell>
+<table:table-cell office:value-type="percentage" office:value="0.125"><text:p>12.5%</text:p></table:table-cell>
+<table:table-cell office:value-type="time" office:time-value="PT10H30M00S"><text:p>10:30:00</text:p></table:table-cell>
+</table:table-row>
+<table:table-row table:visibility="collapse">
+<table:table-cell office:value-type="float" office:value="1002"><text:p>1002</text:p></table:table-cell>
+<table:table-cell table:number-columns-repeated="2"/>
+<table:table-cell office:value-type="date" office:date-value="2026-10-01"><text:p>2026-10-01</text:p></table:table-cell>
+</table:table-row>
+<table:table-row table:number-rows-repeated="1048573"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>
+</table:table>
+</office:spreadsheet></office:body>
+</office:document-content>`
+
+// odsPackage zips content.xml behind the stored mimetype entry that
+// identifies an OpenDocument spreadsheet.
+func odsPackage(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, entry := range []struct {
+		name   string
+		data   string
+		method uint16
+	}{
+		{"mimetype", odsMimeType, zip.Store},
+		{"content.xml", content, zip.Deflate},
+	} {
+		writer, err := archive.CreateHeader(&zip.FileHeader{Name: entry.name, Method: entry.method})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write([]byte(entry.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestODSWorkbookOperations(t *testing.T) {
+	data := odsPackage(t, odsTestContent)
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--size"}, "Rows:3,Cols:4\nHiddenRows:3\nHiddenCols:D\n"},
+		{[]string{"--rows", "1", "3"}, ",A,B,C,D\n1,ID,Name,Rate,Time\n2,1001,攻击  提升,12.5%,10:30:00\n3,1002,,,2026-10-01\n"},
+		{[]string{"--rows", "1", "3", "--skip-hidden", "--values", "raw"}, ",A,B,C\n1,ID,Name,Rate\n2,1001,攻击  提升,0.125\n"},
+	}
+	for _, tt := range tests {
+		if got := runOperation(t, "buffs.ods", data, tt.args...); got != tt.want {
+			t.Errorf("%v:\ngot:\n%s\nwant:\n%s", tt.args, got, tt.want)
+		}
+	}
+}
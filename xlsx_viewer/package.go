@@ -0,0 +1,188 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type workbookSheets struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type packageRelationships struct {
+	Relationships []struct {
+		ID         string `xml:"Id,attr"`
+		Type       string `xml:"Type,attr"`
+		Target     string `xml:"Target,attr"`
+		TargetMode string `xml:"TargetMode,attr"`
+	} `xml:"Relationship"`
+}
+
+// packagePart reads a raw part of the xlsx package. excelize keeps small parts
+// in memory; large worksheets are spilled to temp files, so fall back to
+// reading the zip on disk.
+func packagePart(file *excelize.File, name string) ([]byte, error) {
+	if content, ok := file.Pkg.Load(name); ok {
+		if data, ok := content.([]byte); ok {
+			return data, nil
+		}
+	}
+	reader, err := zip.OpenReader(file.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	for _, entry := range reader.File {
+		if entry.Name != name {
+			continue
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = rc.Close()
+		}()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("文件中没有 %s", name)
+}
+
+func sheetPartName(file *excelize.File, sheet string) (string, error) {
+	content, err := packagePart(file, "xl/workbook.xml")
+	if err != nil {
+		return "", err
+	}
+	var workbook workbookSheets
+	if err := xml.Unmarshal(content, &workbook); err != nil {
+		return "", err
+	}
+	relID := ""
+	for _, item := range workbook.Sheets {
+		if strings.EqualFold(item.Name, sheet) {
+			relID = item.ID
+			break
+		}
+	}
+	if relID == "" {
+		return "", fmt.Errorf("找不到 sheet: %s", sheet)
+	}
+	content, err = packagePart(file, "xl/_rels/workbook.xml.rels")
+	if err != nil {
+		return "", err
+	}
+	var rels packageRelationships
+	if err := xml.Unmarshal(content, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID == relID {
+			return resolvePartTarget("xl", rel.Target), nil
+		}
+	}
+	return "", fmt.Errorf("找不到 sheet: %s", sheet)
+}
+
+func resolvePartTarget(base, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(base, target)
+}
+
+// sheetElement decodes the first top-level worksheet element with the given
+// name into v, skipping the cell data; found is false when the sheet has none.
+func sheetElement(file *excelize.File, sheet, local string, v interface{}) (bool, error) {
+	name, err := sheetPartName(file, sheet)
+	if err != nil {
+		return false, err
+	}
+	content, err := packagePart(file, name)
+	if err != nil {
+		return false, err
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case local:
+			if err := decoder.DecodeElement(v, &start); err != nil {
+				return false, err
+			}
+			return true, nil
+		case "sheetData":
+			if err := decoder.Skip(); err != nil {
+				return false, err
+			}
+		}
+	}
+}
+
+// sheetRelationships maps relationship IDs of a worksheet part to their
+// targets; internal targets are resolved to package part names.
+func sheetRelationships(file *excelize.File, sheet string) (map[string]string, error) {
+	name, err := sheetPartName(file, sheet)
+	if err != nil {
+		return nil, err
+	}
+	return partRelationships(file, name)
+}
+
+func partRelationships(file *excelize.File, name string) (map[string]string, error) {
+	dir, base := path.Split(name)
+	content, err := packagePart(file, dir+"_rels/"+base+".rels")
+	if err != nil {
+		return map[string]string{}, nil
+	}
+	var rels packageRelationships
+	if err := xml.Unmarshal(content, &rels); err != nil {
+		return nil, err
+	}
+	targets := make(map[string]string, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		if rel.TargetMode == "External" {
+			targets[rel.ID] = rel.Target
+		} else {
+			targets[rel.ID] = resolvePartTarget(strings.TrimSuffix(dir, "/"), rel.Target)
+		}
+	}
+	return targets, nil
+}
+
+// packagePartNames lists the parts excelize holds in memory; only oversized
+// worksheets are missing, which is fine for detecting optional parts.
+func packagePartNames(file *excelize.File) []string {
+	names := []string{}
+	file.Pkg.Range(func(key, _ interface{}) bool {
+		if name, ok := key.(string); ok {
+			names = append(names, name)
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
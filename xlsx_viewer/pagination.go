@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type page struct {
+	start int
+	end   int
+	total int
+}
+
+func (p page) partial() bool {
+	return p.start > 0 || p.end < p.total
+}
+
+func paginate(total, offset, size int) page {
+	start := offset
+	if start > total {
+		start = total
+	}
+	end := total
+	if size > 0 && start+size < total {
+		end = start + size
+	}
+	return page{start: start, end: end, total: total}
+}
+
+func applyPage(indexes []int, opts options, size int) ([]int, page) {
+	p := paginate(len(indexes), opts.offset, size)
+	if opts.offset > 0 && p.start >= p.total {
+		printWarning(fmt.Sprintf("--offset %d 超出结果数量 %d", opts.offset, p.total))
+	}
+	return indexes[p.start:p.end], p
+}
+
+// querySignature ties a cursor to the query that produced it so a token
+// cannot silently be replayed against a different file, selection, keyword,
+// filter or value rendering. The sheet is always the first sheet of --path,
+// so the path stands for it.
+func querySignature(opts options) uint32 {
+	h := fnv.New32a()
+	parts := []string{
+		filepath.Clean(opts.path),
+		strconv.Itoa(int(opts.op)),
+		opts.rowsRaw,
+		opts.colsRaw,
+		opts.searchIx,
+		opts.keyword,
+		opts.mode,
+		strconv.Itoa(opts.tail),
+		opts.tableName,
+		strconv.Itoa(opts.headerRows),
+		strconv.FormatBool(opts.skipHidden),
+		strconv.FormatBool(opts.formulas),
+		strconv.FormatBool(opts.calc),
+		opts.valueMode,
+		opts.dateFormat,
+		opts.merged,
+		strconv.FormatBool(opts.comments),
+		strconv.FormatBool(opts.markHidden),
+		opts.richText,
+		strconv.FormatBool(opts.hyperlinks),
+	}
+	for _, clause := range opts.where {
+		parts = append(parts, clause.raw)
+	}
+	_, _ = h.Write([]byte(strings.Join(parts, "\x00")))
+	return h.Sum32()
+}
+
+func encodeCursor(opts options, offset int) string {
+	raw := fmt.Sprintf("%d:%x", offset, querySignature(opts))
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(opts options, token string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errors.New("无效的 --cursor")
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return 0, errors.New("无效的 --cursor")
+	}
+	offset, err := strconv.Atoi(parts[0])
+	if err != nil || offset < 0 {
+		return 0, errors.New("无效的 --cursor")
+	}
+	if parts[1] != fmt.Sprintf("%x", querySignature(opts)) {
+		return 0, errors.New("--cursor 与当前查询不匹配")
+	}
+	return offset, nil
+}
+
+func printPageInfo(p page, unit string) {
+	if !p.partial() {
+		return
+	}
+	if p.start >= p.end {
+		printLine("分页: 共 %d %s, 本页无数据", p.total, unit)
+		return
+	}
+	printLine("分页: 共 %d %s, 显示第 %d-%d %s", p.total, unit, p.start+1, p.end, unit)
+}
+
+func printPageFooter(p page, opts options) {
+	if p.end >= p.total || limits.truncated {
+		return
+	}
+	fmt.Printf("下一页: --cursor %s (或 --offset %d), 剩余 %d 个\n", encodeCursor(opts, p.end), p.end, p.total-p.end)
+}
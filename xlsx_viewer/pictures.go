@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type sheetPicture struct {
+	cell    string
+	picture excelize.Picture
+}
+
+var pictureInsertTypes = map[excelize.PictureInsertType]string{
+	excelize.PictureInsertTypePlaceOverCells: "浮动",
+	excelize.PictureInsertTypePlaceInCell:    "嵌入单元格",
+	excelize.PictureInsertTypeIMAGE:          "IMAGE函数",
+	excelize.PictureInsertTypeDISPIMG:        "DISPIMG",
+}
+
+func sheetPictures(file *excelize.File, sheet string) ([]sheetPicture, error) {
+	cells, err := file.GetPictureCells(sheet)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		ci, ri, _ := excelize.CellNameToCoordinates(cells[i])
+		cj, rj, _ := excelize.CellNameToCoordinates(cells[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return ci < cj
+	})
+	result := []sheetPicture{}
+	for _, cell := range cells {
+		pictures, err := file.GetPictures(sheet, cell)
+		if err != nil {
+			return nil, err
+		}
+		for _, picture := range pictures {
+			result = append(result, sheetPicture{cell: cell, picture: picture})
+		}
+	}
+	return result, nil
+}
+
+func pictureFormat(picture excelize.Picture) string {
+	return strings.ToLower(strings.TrimPrefix(picture.Extension, "."))
+}
+
+func pictureDimensions(picture excelize.Picture) string {
+	config, _, err := image.DecodeConfig(bytes.NewReader(picture.File))
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%dx%d", config.Width, config.Height)
+}
+
+func pictureAltText(picture excelize.Picture) string {
+	if picture.Format == nil {
+		return ""
+	}
+	return picture.Format.AltText
+}
+
+func handleListPictures(file *excelize.File, sheet string) {
+	pictures, err := sheetPictures(file, sheet)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	if !printLine("图片: 共 %d 个", len(pictures)) {
+		printOutputTruncated()
+		return
+	}
+	if len(pictures) == 0 {
+		return
+	}
+	printCSVRow([]string{"单元格", "格式", "字节数", "像素尺寸", "插入方式", "替代文字"})
+	for i, item := range pictures {
+		line := []string{
+			item.cell,
+			pictureFormat(item.picture),
+			strconv.Itoa(len(item.picture.File)),
+			pictureDimensions(item.picture),
+			pictureInsertTypes[item.picture.InsertType],
+			truncateCell(pictureAltText(item.picture)),
+		}
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(pictures))
+			return
+		}
+	}
+}
+
+// pictureFileName names an extracted picture after its sheet and anchor cell;
+// several pictures anchored to the same cell get a numeric suffix.
+func pictureFileName(sheet, cell string, index int, extension string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, sheet) + "_" + cell
+	if index > 1 {
+		name += "_" + strconv.Itoa(index)
+	}
+	return name + strings.ToLower(extension)
+}
+
+func handleExtractPictures(file *excelize.File, sheet string, opts options) {
+	pictures, err := sheetPictures(file, sheet)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	if err := os.MkdirAll(opts.pictureDir, 0o755); err != nil {
+		exitWithError(fmt.Sprintf("无法创建目录: %s", opts.pictureDir))
+	}
+	printLine("导出图片: 共 %d 个, 目录 %s", len(pictures), opts.pictureDir)
+	if len(pictures) == 0 {
+		return
+	}
+	printCSVRow([]string{"单元格", "文件", "字节数"})
+	seen := map[string]int{}
+	for _, item := range pictures {
+		seen[item.cell]++
+		name := pictureFileName(sheet, item.cell, seen[item.cell], item.picture.Extension)
+		target := filepath.Join(opts.pictureDir, name)
+		if err := os.WriteFile(target, item.picture.File, 0o644); err != nil {
+			exitWithError(fmt.Sprintf("无法写入文件: %s", target))
+		}
+		printCSVRow([]string{item.cell, target, strconv.Itoa(len(item.picture.File))})
+	}
+	// Every picture is still written; only the listing is cut short.
+	if limits.truncated {
+		printOutputTruncated()
+	}
+}
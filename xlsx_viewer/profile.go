@@ -0,0 +1,317 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	defaultTopN       = 5
+	defaultHeaderRows = 1
+	profileSampleSize = 3
+)
+
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006-1-2",
+	"2006/1/2",
+	"2006-01-02 15:04:05",
+	"2006/01/02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/1/2 15:04",
+	"2006-01-02T15:04:05",
+	"01-02-06",
+	"1-2-06",
+	"01-02-06 15:04",
+	"1/2/06",
+	"1/2/06 15:04",
+}
+
+type columnProfile struct {
+	col       int
+	header    string
+	nonEmpty  int
+	counts    map[string]int
+	order     []string
+	numeric   int
+	ints      int
+	bools     int
+	dates     int
+	arrays    int
+	min       float64
+	max       float64
+	maxLength int
+}
+
+func newColumnProfile(col int) *columnProfile {
+	return &columnProfile{
+		col:    col,
+		counts: map[string]int{},
+		min:    math.Inf(1),
+		max:    math.Inf(-1),
+	}
+}
+
+// add records one cell. The formatted value feeds the counts, top values and
+// samples; the raw value decides the type and min/max, since number formats
+// such as #,##0 or 0% hide the stored number.
+func (p *columnProfile) add(value, raw string) {
+	if strings.TrimSpace(value) == "" {
+		return
+	}
+	p.nonEmpty++
+	if _, ok := p.counts[value]; !ok {
+		p.order = append(p.order, value)
+	}
+	p.counts[value]++
+	if length := len([]rune(value)); length > p.maxLength {
+		p.maxLength = length
+	}
+	if raw == "" || inferValueType(value) == "bool" {
+		raw = value
+	}
+	switch kind := inferValueType(raw); kind {
+	case "int", "float":
+		p.numeric++
+		if kind == "int" {
+			p.ints++
+		}
+		number, _ := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if number < p.min {
+			p.min = number
+		}
+		if number > p.max {
+			p.max = number
+		}
+	case "bool":
+		p.bools++
+	case "date":
+		p.dates++
+	case "array":
+		p.arrays++
+	}
+}
+
+func (p *columnProfile) inferredType() string {
+	switch {
+	case p.nonEmpty == 0:
+		return "empty"
+	case p.ints == p.nonEmpty:
+		return "int"
+	case p.numeric == p.nonEmpty:
+		return "float"
+	case p.bools == p.nonEmpty:
+		return "bool"
+	case p.dates == p.nonEmpty:
+		return "date"
+	case p.arrays > 0 && p.arrays+p.numeric == p.nonEmpty:
+		return "array"
+	default:
+		return "string"
+	}
+}
+
+func (p *columnProfile) topValues(n int) []string {
+	values := append([]string(nil), p.order...)
+	sort.SliceStable(values, func(i, j int) bool {
+		return p.counts[values[i]] > p.counts[values[j]]
+	})
+	if len(values) > n {
+		values = values[:n]
+	}
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = fmt.Sprintf("%s(%d)", value, p.counts[value])
+	}
+	return result
+}
+
+func (p *columnProfile) samples() []string {
+	if len(p.order) > profileSampleSize {
+		return p.order[:profileSampleSize]
+	}
+	return p.order
+}
+
+func inferValueType(value string) string {
+	value = strings.TrimSpace(value)
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return "int"
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return "float"
+	}
+	switch strings.ToLower(value) {
+	case "true", "false":
+		return "bool"
+	}
+	if _, ok := parseDateText(value); ok {
+		return "date"
+	}
+	if isArrayText(value) {
+		return "array"
+	}
+	return "string"
+}
+
+func parseDateText(value string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func isArrayText(value string) bool {
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		return true
+	}
+	for _, sep := range []string{"|", ";", ","} {
+		parts := strings.Split(value, sep)
+		if len(parts) < 2 {
+			continue
+		}
+		allNumeric := true
+		for _, part := range parts {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(part), 64); err != nil {
+				allNumeric = false
+				break
+			}
+		}
+		if allNumeric {
+			return true
+		}
+	}
+	return false
+}
+
+func handleProfile(file *excelize.File, sheet string, totalRows, totalCols int, opts options) {
+	colIndexes := make([]int, 0, totalCols)
+	if opts.colsRaw == "" {
+		for col := 1; col <= totalCols; col++ {
+			colIndexes = append(colIndexes, col)
+		}
+	} else {
+		selected, requestedMax, err := parseColumnRange(opts.colsRaw, totalCols)
+		if err != nil {
+			exitWithUsageError(err.Error())
+		}
+		if requestedMax > totalCols {
+			printWarning(fmt.Sprintf("请求%d列，但文件只有%d列", requestedMax, totalCols))
+		}
+		colIndexes = selected
+	}
+	colIndexes = visibleCols(file, sheet, colIndexes)
+
+	profiles := make([]*columnProfile, len(colIndexes))
+	for i, col := range colIndexes {
+		profiles[i] = newColumnProfile(col)
+	}
+
+	rows, err := file.Rows(sheet)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	rawRows, err := file.Rows(sheet)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	defer func() {
+		_ = rawRows.Close()
+	}()
+	dataRows := totalRows - opts.headerRows
+	if dataRows < 0 {
+		dataRows = 0
+	}
+	rowIdx := 0
+	for rows.Next() && rowIdx < totalRows {
+		rowIdx++
+		values, err := rows.Columns()
+		if err != nil {
+			exitWithError(err.Error())
+		}
+		rawRows.Next()
+		rawValues, err := rawRows.Columns(excelize.Options{RawCellValue: true})
+		if err != nil {
+			exitWithError(err.Error())
+		}
+		if rowIdx > opts.headerRows && display.skipHidden && rowHidden(file, sheet, rowIdx) {
+			dataRows--
+			continue
+		}
+		for _, p := range profiles {
+			value, raw := "", ""
+			if p.col <= len(values) {
+				value = values[p.col-1]
+			}
+			if p.col <= len(rawValues) {
+				raw = rawValues[p.col-1]
+			}
+			if value == "" && display.merged == "fill" {
+				if area, covered := isMergedCovered(file, sheet, rowIdx, p.col); covered {
+					value, _ = cellValue(file, sheet, area.startRow, area.startCol)
+					origin, _ := excelize.CoordinatesToCellName(area.startCol, area.startRow)
+					raw, _ = file.GetCellValue(sheet, origin, excelize.Options{RawCellValue: true})
+				}
+			}
+			if value != "" && rowIdx > opts.headerRows {
+				cell, _ := excelize.CoordinatesToCellName(p.col, rowIdx)
+				if date, ok := dateValue(file, sheet, cell); ok {
+					value, raw = date, date
+				}
+			}
+			if rowIdx == opts.headerRows {
+				p.header = value
+			}
+			if rowIdx > opts.headerRows {
+				p.add(value, raw)
+			}
+		}
+	}
+	if err := rows.Error(); err != nil {
+		exitWithError(err.Error())
+	}
+
+	if !printLine("字段概况: 共 %d 列, %d 行数据", len(profiles), dataRows) {
+		printOutputTruncated()
+		return
+	}
+	if !printCSVRow([]string{"", "表头", "类型", "非空", "唯一值", "最小值", "最大值", "最大长度", "高频值", "样例值"}) {
+		printRowsTruncated(0, len(profiles))
+		return
+	}
+	for i, p := range profiles {
+		minValue, maxValue := "", ""
+		if p.numeric > 0 {
+			minValue = strconv.FormatFloat(p.min, 'f', -1, 64)
+			maxValue = strconv.FormatFloat(p.max, 'f', -1, 64)
+		}
+		line := []string{
+			numberToColumn(p.col),
+			p.header,
+			p.inferredType(),
+			strconv.Itoa(p.nonEmpty),
+			strconv.Itoa(len(p.counts)),
+			minValue,
+			maxValue,
+			strconv.Itoa(p.maxLength),
+			strings.Join(p.topValues(opts.topN), "; "),
+			strings.Join(p.samples(), "; "),
+		}
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(profiles))
+			return
+		}
+	}
+}
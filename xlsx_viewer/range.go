@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type cellArea struct {
+	label    string
+	sheet    string
+	startRow int
+	endRow   int
+	startCol int
+	endCol   int
+	maxRow   int
+	maxCol   int
+}
+
+// parseAreas accepts A1 areas, "Sheet2!A1:C5" and defined names; areas on
+// another sheet are bounded by that sheet's own size.
+func parseAreas(file *excelize.File, sheet, input string, totalRows, totalCols int) ([]cellArea, error) {
+	sizes := map[string][2]int{strings.ToLower(sheet): {totalRows, totalCols}}
+	areas := []cellArea{}
+	for _, item := range strings.Split(input, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		ref, named := definedNameRef(file, sheet, item)
+		if !named {
+			ref = item
+		}
+		areaSheet, ref := splitSheetRef(ref, sheet)
+		label := item
+		if !named {
+			label = ref
+			if strings.Contains(item, "!") {
+				label = areaSheet + "!" + ref
+			}
+		}
+		size, ok := sizes[strings.ToLower(areaSheet)]
+		if !ok {
+			rows, cols, err := sheetSize(file, areaSheet)
+			if err != nil {
+				return nil, fmt.Errorf("无效区域: %s", item)
+			}
+			size = [2]int{rows, cols}
+			sizes[strings.ToLower(areaSheet)] = size
+		}
+		area, err := parseArea(ref, size[0], size[1])
+		if err != nil {
+			return nil, fmt.Errorf("无效区域: %s", item)
+		}
+		area.label, area.sheet = label, areaSheet
+		area.maxRow, area.maxCol = size[0], size[1]
+		areas = append(areas, area)
+	}
+	if len(areas) == 0 {
+		return nil, fmt.Errorf("未指定有效区域")
+	}
+	return areas, nil
+}
+
+func parseArea(item string, totalRows, totalCols int) (cellArea, error) {
+	// Column letters are matched in upper case, as --cols accepts "c" too.
+	parts := strings.Split(strings.ToUpper(item), ":")
+	if len(parts) > 2 {
+		return cellArea{}, fmt.Errorf("无效区域: %s", item)
+	}
+	if len(parts) == 1 {
+		parts = append(parts, parts[0])
+	}
+	area := cellArea{label: item}
+	switch {
+	case isNumeric(parts[0]) && isNumeric(parts[1]):
+		start, _ := strconv.Atoi(parts[0])
+		end, _ := strconv.Atoi(parts[1])
+		if start <= 0 || end <= 0 {
+			return cellArea{}, fmt.Errorf("无效区域: %s", item)
+		}
+		area.startRow, area.endRow = start, end
+		area.startCol, area.endCol = 1, totalCols
+	case isColumnLetters(parts[0]) && isColumnLetters(parts[1]):
+		area.startCol, _ = parseColumnIndex(parts[0])
+		area.endCol, _ = parseColumnIndex(parts[1])
+		area.startRow, area.endRow = 1, totalRows
+	default:
+		startCol, startRow, err := excelize.CellNameToCoordinates(parts[0])
+		if err != nil {
+			return cellArea{}, fmt.Errorf("无效区域: %s", item)
+		}
+		endCol, endRow, err := excelize.CellNameToCoordinates(parts[1])
+		if err != nil {
+			return cellArea{}, fmt.Errorf("无效区域: %s", item)
+		}
+		area.startRow, area.endRow = startRow, endRow
+		area.startCol, area.endCol = startCol, endCol
+	}
+	if area.startRow > area.endRow {
+		area.startRow, area.endRow = area.endRow, area.startRow
+	}
+	if area.startCol > area.endCol {
+		area.startCol, area.endCol = area.endCol, area.startCol
+	}
+	return area, nil
+}
+
+func isColumnLetters(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, ch := range value {
+		if ch < 'A' || ch > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func handleRange(file *excelize.File, sheet string, totalRows, totalCols int, opts options) {
+	areas, err := parseAreas(file, sheet, opts.rangeRaw, totalRows, totalCols)
+	if err != nil {
+		exitWithUsageError(err.Error())
+	}
+	for i, area := range areas {
+		if limits.truncated {
+			break
+		}
+		if area.endRow > area.maxRow {
+			printWarning(fmt.Sprintf("区域 %s 请求到第%d行，但文件只有%d行", area.label, area.endRow, area.maxRow))
+			area.endRow = area.maxRow
+		}
+		if area.endCol > area.maxCol {
+			printWarning(fmt.Sprintf("区域 %s 请求到第%d列，但文件只有%d列", area.label, area.endCol, area.maxCol))
+			area.endCol = area.maxCol
+		}
+		if len(areas) > 1 {
+			if i > 0 && !printLine("") {
+				printOutputTruncated()
+				break
+			}
+			if !printLine("区域: %s", area.label) {
+				printOutputTruncated()
+				break
+			}
+		}
+		rowIndexes := make([]int, 0, area.endRow-area.startRow+1)
+		for row := area.startRow; row <= area.endRow; row++ {
+			rowIndexes = append(rowIndexes, row)
+		}
+		colIndexes := make([]int, 0, area.endCol-area.startCol+1)
+		for col := area.startCol; col <= area.endCol; col++ {
+			colIndexes = append(colIndexes, col)
+		}
+		rowIndexes = visibleRows(file, area.sheet, rowIndexes)
+		colIndexes = visibleCols(file, area.sheet, colIndexes)
+		data := make([][]string, 0, len(rowIndexes))
+		for _, row := range rowIndexes {
+			values, err := readCells(file, area.sheet, row, colIndexes)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+			data = append(data, values)
+		}
+		printGridData(file, area.sheet, data, rowIndexes, colIndexes)
+	}
+}
+
+func readCells(file *excelize.File, sheet string, rowIndex int, colIndexes []int) ([]string, error) {
+	row := make([]string, len(colIndexes))
+	for i, col := range colIndexes {
+		value, err := displayValue(file, sheet, rowIndex, col)
+		if err != nil {
+			return nil, err
+		}
+		row[i] = value
+	}
+	return row, nil
+}
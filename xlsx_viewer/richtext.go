@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type richTextRun struct {
+	Text      string  `json:"text"`
+	Bold      bool    `json:"bold,omitempty"`
+	Italic    bool    `json:"italic,omitempty"`
+	Underline bool    `json:"underline,omitempty"`
+	Strike    bool    `json:"strike,omitempty"`
+	Color     string  `json:"color,omitempty"`
+	Size      float64 `json:"size,omitempty"`
+	Font      string  `json:"font,omitempty"`
+}
+
+func parseRichTextMode(value string) (string, error) {
+	value = strings.ToLower(value)
+	switch value {
+	case "":
+		return "unity", nil
+	case "unity", "json":
+		return value, nil
+	default:
+		return "", fmt.Errorf("--rich-text 只能是 unity, json")
+	}
+}
+
+func richTextRuns(file *excelize.File, sheet, cell string) ([]richTextRun, error) {
+	runs, err := file.GetCellRichText(sheet, cell)
+	if err != nil || len(runs) == 0 {
+		return nil, err
+	}
+	result := make([]richTextRun, len(runs))
+	for i, run := range runs {
+		result[i] = richTextRun{Text: run.Text}
+		if run.Font == nil {
+			continue
+		}
+		result[i].Bold = run.Font.Bold
+		result[i].Italic = run.Font.Italic
+		result[i].Underline = run.Font.Underline != "" && run.Font.Underline != "none"
+		result[i].Strike = run.Font.Strike
+		result[i].Color = fontColor(run.Font.Color)
+		result[i].Size = run.Font.Size
+		result[i].Font = run.Font.Family
+	}
+	return result, nil
+}
+
+// fontColor normalizes excelize ARGB colors (FFFF0000) to #RRGGBB as used by
+// Unity rich text; theme and indexed colors carry no RGB and are dropped.
+func fontColor(color string) string {
+	color = strings.TrimPrefix(strings.ToUpper(color), "#")
+	if len(color) == 8 {
+		color = color[2:]
+	}
+	if len(color) != 6 {
+		return ""
+	}
+	return "#" + color
+}
+
+// styled reports whether the run carries formatting that the plain value
+// would lose; size and font family alone do not count.
+func (r richTextRun) styled() bool {
+	return r.Bold || r.Italic || r.Underline || r.Strike || r.Color != ""
+}
+
+// richTextValue renders the runs of a rich text cell. A plain string, which
+// excelize also reports as a single unstyled run, keeps its plain value.
+func richTextValue(file *excelize.File, sheet, cell string) (string, bool, error) {
+	runs, err := richTextRuns(file, sheet, cell)
+	if err != nil || len(runs) == 0 {
+		return "", false, err
+	}
+	if len(runs) == 1 && !runs[0].styled() {
+		return "", false, nil
+	}
+	if display.richText == "json" {
+		data, err := json.Marshal(runs)
+		if err != nil {
+			return "", false, err
+		}
+		return string(data), true, nil
+	}
+	var builder strings.Builder
+	for _, run := range runs {
+		builder.WriteString(unityMarkup(run))
+	}
+	return builder.String(), true, nil
+}
+
+func unityMarkup(run richTextRun) string {
+	text := run.Text
+	if run.Strike {
+		text = "<s>" + text + "</s>"
+	}
+	if run.Underline {
+		text = "<u>" + text + "</u>"
+	}
+	if run.Italic {
+		text = "<i>" + text + "</i>"
+	}
+	if run.Bold {
+		text = "<b>" + text + "</b>"
+	}
+	if run.Color != "" {
+		text = "<color=" + run.Color + ">" + text + "</color>"
+	}
+	return text
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type sourceCellKind int
+
+const (
+	sourceText sourceCellKind = iota
+	sourceNumber
+	sourceBool
+	sourceError
+)
+
+type sourceCell struct {
+	row    int
+	col    int
+	kind   sourceCellKind
+	text   string
+	number float64
+	format string
+}
+
+type sourceSheet struct {
+	name       string
+	hidden     bool
+	cells      []sourceCell
+	hiddenRows []int
+	hiddenCols []int
+	merged     []string
+}
+
+type sourceWorkbook struct {
+	kind     workbookKind
+	sheets   []sourceSheet
+	date1904 bool
+}
+
+// workbookReader is implemented by the readers for formats excelize cannot
+// open. Their sheets are loaded into an in-memory workbook, so every
+// operation and output format behaves the same as for xlsx.
+type workbookReader interface {
+	read(path string) (*sourceWorkbook, error)
+}
+
+var loadedSources = map[string]workbookKind{}
+
+func loadSourceWorkbook(path string, reader workbookReader) (*excelize.File, error) {
+	book, err := reader.read(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(book.sheets) == 0 {
+		return nil, fmt.Errorf("文件中没有可用的 sheet")
+	}
+	file := excelize.NewFile()
+	defer func() {
+		_ = file.Close()
+	}()
+	styles := map[string]int{}
+	for i, sheet := range book.sheets {
+		if i == 0 {
+			if err := file.SetSheetName(file.GetSheetName(0), sheet.name); err != nil {
+				return nil, err
+			}
+		} else if _, err := file.NewSheet(sheet.name); err != nil {
+			return nil, err
+		}
+		if err := writeSourceSheet(file, sheet, styles); err != nil {
+			return nil, fmt.Errorf("%s: %s", sheet.name, err.Error())
+		}
+	}
+	for _, sheet := range book.sheets {
+		if sheet.hidden {
+			_ = file.SetSheetVisible(sheet.name, false)
+		}
+	}
+	if book.date1904 {
+		date1904 := true
+		if err := file.SetWorkbookProps(&excelize.WorkbookPropsOptions{Date1904: &date1904}); err != nil {
+			return nil, err
+		}
+	}
+	buf, err := file.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	loaded, err := excelize.OpenReader(buf)
+	if err != nil {
+		return nil, err
+	}
+	// Drop the document properties excelize generated for the in-memory
+	// workbook so --info does not report them as the source file's.
+	loaded.Pkg.Delete("docProps/core.xml")
+	loaded.Pkg.Delete("docProps/app.xml")
+	loaded.Path = path
+	loadedSources[path] = book.kind
+	return loaded, nil
+}
+
+func writeSourceSheet(file *excelize.File, sheet sourceSheet, styles map[string]int) error {
+	for _, cell := range sheet.cells {
+		name, err := excelize.CoordinatesToCellName(cell.col, cell.row)
+		if err != nil {
+			return err
+		}
+		switch cell.kind {
+		case sourceNumber:
+			err = file.SetCellFloat(sheet.name, name, cell.number, -1, 64)
+		case sourceBool:
+			err = file.SetCellBool(sheet.name, name, cell.text == "TRUE")
+		default:
+			err = file.SetCellStr(sheet.name, name, cell.text)
+		}
+		if err != nil {
+			return err
+		}
+		if cell.format == "" || cell.format == "General" {
+			continue
+		}
+		style, ok := styles[cell.format]
+		if !ok {
+			format := cell.format
+			if style, err = file.NewStyle(&excelize.Style{CustomNumFmt: &format}); err != nil {
+				return err
+			}
+			styles[cell.format] = style
+		}
+		if err := file.SetCellStyle(sheet.name, name, name, style); err != nil {
+			return err
+		}
+	}
+	for _, row := range sheet.hiddenRows {
+		if err := file.SetRowVisible(sheet.name, row, false); err != nil {
+			return err
+		}
+	}
+	for _, col := range sheet.hiddenCols {
+		if err := file.SetColVisible(sheet.name, numberToColumn(col), false); err != nil {
+			return err
+		}
+	}
+	for _, ref := range sheet.merged {
+		area, err := parseArea(ref, 0, 0)
+		if err != nil {
+			continue
+		}
+		start, _ := excelize.CoordinatesToCellName(area.startCol, area.startRow)
+		end, _ := excelize.CoordinatesToCellName(area.endCol, area.endRow)
+		if err := file.MergeCell(sheet.name, start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var validationOperators = map[string]string{
+	"":                   "介于",
+	"between":            "介于",
+	"notBetween":         "不介于",
+	"equal":              "等于",
+	"notEqual":           "不等于",
+	"greaterThan":        "大于",
+	"lessThan":           "小于",
+	"greaterThanOrEqual": "大于等于",
+	"lessThanOrEqual":    "小于等于",
+}
+
+type validationRule struct {
+	dv      *excelize.DataValidation
+	areas   []cellArea
+	allowed map[string]bool
+	source  string
+}
+
+func loadValidationRules(file *excelize.File, sheet string, totalRows, totalCols int) ([]validationRule, error) {
+	dvs, err := file.GetDataValidations(sheet)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]validationRule, 0, len(dvs))
+	for _, dv := range dvs {
+		rule := validationRule{dv: dv}
+		for _, ref := range strings.Fields(dv.Sqref) {
+			area, err := parseArea(strings.ToUpper(strings.ReplaceAll(ref, "$", "")), totalRows, totalCols)
+			if err != nil {
+				continue
+			}
+			rule.areas = append(rule.areas, area)
+		}
+		if dv.Type == "list" {
+			values, source, err := validationListValues(file, sheet, dv.Formula1)
+			if err != nil {
+				printWarning(fmt.Sprintf("无法读取 %s 的下拉列表来源 %s: %s", dv.Sqref, dv.Formula1, err.Error()))
+			} else {
+				rule.allowed = map[string]bool{}
+				for _, value := range values {
+					rule.allowed[value] = true
+				}
+			}
+			rule.source = source
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// validationListValues resolves a list rule's formula1, which is either a
+// quoted literal ("a,b,c"), a range reference, or a defined name.
+func validationListValues(file *excelize.File, sheet, formula string) ([]string, string, error) {
+	formula = strings.TrimPrefix(strings.TrimSpace(formula), "=")
+	if strings.HasPrefix(formula, `"`) && strings.HasSuffix(formula, `"`) && len(formula) >= 2 {
+		literal := strings.ReplaceAll(formula[1:len(formula)-1], `""`, `"`)
+		values := strings.Split(literal, ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+		return values, strings.Join(values, ","), nil
+	}
+	ref := formula
+	if refersTo, ok := definedNameRef(file, sheet, formula); ok {
+		ref = refersTo
+	}
+	refSheet, ref := splitSheetRef(ref, sheet)
+	area, err := parseArea(ref, 0, 0)
+	if err != nil {
+		return nil, formula, err
+	}
+	values := []string{}
+	for row := area.startRow; row <= area.endRow; row++ {
+		for col := area.startCol; col <= area.endCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				return nil, formula, err
+			}
+			value, err := file.GetCellValue(refSheet, cell)
+			if err != nil {
+				return nil, formula, err
+			}
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values, fmt.Sprintf("%s (%s)", formula, strings.Join(values, ",")), nil
+}
+
+func validationCondition(dv *excelize.DataValidation) string {
+	switch dv.Type {
+	case "list", "custom", "any", "":
+		return ""
+	}
+	op := validationOperators[dv.Operator]
+	if op == "" {
+		op = dv.Operator
+	}
+	if dv.Operator == "" || dv.Operator == "between" || dv.Operator == "notBetween" {
+		return fmt.Sprintf("%s %s 和 %s", op, dv.Formula1, dv.Formula2)
+	}
+	return fmt.Sprintf("%s %s", op, dv.Formula1)
+}
+
+func handleValidations(file *excelize.File, sheet string, totalRows, totalCols int) {
+	rules, err := loadValidationRules(file, sheet, totalRows, totalCols)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	if !printLine("数据验证: 共 %d 条规则", len(rules)) {
+		printOutputTruncated()
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+	printCSVRow([]string{"区域", "类型", "条件", "列表来源", "最小值", "最大值", "允许空值", "提示"})
+	for i, rule := range rules {
+		dv := rule.dv
+		minValue, maxValue := "", ""
+		if dv.Type != "list" && dv.Type != "custom" {
+			minValue, maxValue = validationBounds(dv)
+		}
+		line := []string{
+			dv.Sqref,
+			dv.Type,
+			validationCondition(dv),
+			truncateCell(rule.source),
+			minValue,
+			maxValue,
+			strconv.FormatBool(dv.AllowBlank),
+			validationMessage(dv),
+		}
+		if dv.Type == "custom" {
+			line[2] = "=" + strings.TrimPrefix(dv.Formula1, "=")
+		}
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(rules))
+			return
+		}
+	}
+}
+
+func validationBounds(dv *excelize.DataValidation) (string, string) {
+	switch dv.Operator {
+	case "", "between", "notBetween":
+		return dv.Formula1, dv.Formula2
+	case "greaterThan", "greaterThanOrEqual":
+		return dv.Formula1, ""
+	case "lessThan", "lessThanOrEqual":
+		return "", dv.Formula1
+	default:
+		return dv.Formula1, dv.Formula1
+	}
+}
+
+func validationMessage(dv *excelize.DataValidation) string {
+	parts := []string{}
+	for _, text := range []*string{dv.PromptTitle, dv.Prompt, dv.ErrorTitle, dv.Error} {
+		if text != nil && strings.TrimSpace(*text) != "" {
+			parts = append(parts, strings.TrimSpace(*text))
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+// checkValue returns an empty reason when value satisfies the rule; ok is
+// false when the rule cannot be evaluated (custom formulas, non-literal bounds).
+func (rule validationRule) checkValue(value, raw string) (reason string, ok bool) {
+	dv := rule.dv
+	switch dv.Type {
+	case "list":
+		if rule.allowed == nil {
+			return "", false
+		}
+		if rule.allowed[strings.TrimSpace(value)] {
+			return "", true
+		}
+		return "不在下拉列表中", true
+	case "whole", "decimal", "date", "time":
+		number, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return "不是数值", true
+		}
+		if dv.Type == "whole" && number != float64(int64(number)) {
+			return "不是整数", true
+		}
+		return compareValidation(dv, number)
+	case "textLength":
+		return compareValidation(dv, float64(utf8.RuneCountInString(value)))
+	default:
+		return "", false
+	}
+}
+
+// evaluable reports whether checkValue can judge values against the rule at
+// all, so blanks are only reported for rules that are actually checked.
+func (rule validationRule) evaluable() bool {
+	switch rule.dv.Type {
+	case "list":
+		return rule.allowed != nil
+	case "whole", "decimal", "date", "time", "textLength":
+		_, ok := compareValidation(rule.dv, 0)
+		return ok
+	default:
+		return false
+	}
+}
+
+func compareValidation(dv *excelize.DataValidation, number float64) (string, bool) {
+	first, err := strconv.ParseFloat(strings.TrimSpace(dv.Formula1), 64)
+	if err != nil {
+		return "", false
+	}
+	second := first
+	if dv.Formula2 != "" {
+		if second, err = strconv.ParseFloat(strings.TrimSpace(dv.Formula2), 64); err != nil {
+			return "", false
+		}
+	}
+	valid := true
+	switch dv.Operator {
+	case "", "between":
+		valid = number >= first && number <= second
+	case "notBetween":
+		valid = number < first || number > second
+	case "equal":
+		valid = number == first
+	case "notEqual":
+		valid = number != first
+	case "greaterThan":
+		valid = number > first
+	case "lessThan":
+		valid = number < first
+	case "greaterThanOrEqual":
+		valid = number >= first
+	case "lessThanOrEqual":
+		valid = number <= first
+	}
+	if valid {
+		return "", true
+	}
+	return "不满足条件: " + validationCondition(dv), true
+}
+
+func handleCheckValidations(file *excelize.File, sheet string, totalRows, totalCols int, opts options) {
+	rules, err := loadValidationRules(file, sheet, totalRows, totalCols)
+	if err != nil {
+		exitWithError(err.Error())
+	}
+	checked, skippedRules := 0, 0
+	results := [][]string{}
+	for _, rule := range rules {
+		evaluated := false
+		checkBlank := !rule.dv.AllowBlank && rule.evaluable()
+		for _, area := range rule.areas {
+			endRow, endCol := area.endRow, area.endCol
+			if endRow > totalRows {
+				endRow = totalRows
+			}
+			if endCol > totalCols {
+				endCol = totalCols
+			}
+			startRow := area.startRow
+			if startRow <= opts.headerRows {
+				startRow = opts.headerRows + 1
+			}
+			for row := startRow; row <= endRow; row++ {
+				for col := area.startCol; col <= endCol; col++ {
+					cell, err := excelize.CoordinatesToCellName(col, row)
+					if err != nil {
+						exitWithError(err.Error())
+					}
+					value, err := cellValue(file, sheet, row, col)
+					if err != nil {
+						exitWithError(err.Error())
+					}
+					if strings.TrimSpace(value) == "" {
+						if checkBlank {
+							evaluated = true
+							checked++
+							results = append(results, []string{cell, "", rule.dv.Sqref, "不允许为空"})
+						}
+						continue
+					}
+					raw, err := file.GetCellValue(sheet, cell, excelize.Options{RawCellValue: true})
+					if err != nil {
+						exitWithError(err.Error())
+					}
+					reason, ok := rule.checkValue(value, raw)
+					if !ok {
+						continue
+					}
+					evaluated = true
+					checked++
+					if reason != "" {
+						results = append(results, []string{cell, truncateCell(value), rule.dv.Sqref, reason})
+					}
+				}
+			}
+		}
+		if !evaluated && rule.dv.Type != "any" {
+			skippedRules++
+		}
+	}
+	if !printLine("验证检查: 共 %d 条规则, 检查 %d 个单元格, %d 个不符合", len(rules), checked, len(results)) {
+		printOutputTruncated()
+		return
+	}
+	if skippedRules > 0 {
+		printWarning(fmt.Sprintf("%d 条规则无法自动检查(自定义公式、引用单元格的边界或无数据)", skippedRules))
+	}
+	if len(results) == 0 {
+		return
+	}
+	printCSVRow([]string{"单元格", "值", "规则区域", "原因"})
+	for i, line := range results {
+		if !printCSVRow(line) {
+			printRowsTruncated(i, len(results))
+			return
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var builtInFormatCodes = map[int]string{
+	0:  "General",
+	1:  "0",
+	2:  "0.00",
+	3:  "#,##0",
+	4:  "#,##0.00",
+	9:  "0%",
+	10: "0.00%",
+	11: "0.00E+00",
+	12: "# ?/?",
+	13: "# ??/??",
+	14: "mm-dd-yy",
+	15: "d-mmm-yy",
+	16: "d-mmm",
+	17: "mmm-yy",
+	18: "h:mm AM/PM",
+	19: "h:mm:ss AM/PM",
+	20: "hh:mm",
+	21: "hh:mm:ss",
+	22: "m/d/yy hh:mm",
+	// East Asian built-in date and time formats, with their zh-CN codes.
+	27: `yyyy"年"m"月"`,
+	28: `m"月"d"日"`,
+	29: `m"月"d"日"`,
+	30: "m-d-yy",
+	31: `yyyy"年"m"月"d"日"`,
+	32: `h"时"mm"分"`,
+	33: `h"时"mm"分"ss"秒"`,
+	34: `上午/下午h"时"mm"分"`,
+	35: `上午/下午h"时"mm"分"ss"秒"`,
+	36: `yyyy"年"m"月"`,
+	37: "#,##0 ;(#,##0)",
+	38: "#,##0 ;[red](#,##0)",
+	39: "#,##0.00 ;(#,##0.00)",
+	40: "#,##0.00 ;[red](#,##0.00)",
+	45: "mm:ss",
+	46: "[h]:mm:ss",
+	47: "mm:ss.0",
+	48: "##0.0E+0",
+	49: "@",
+	50: `yyyy"年"m"月"`,
+	51: `m"月"d"日"`,
+	52: `yyyy"年"m"月"`,
+	53: `m"月"d"日"`,
+	54: `m"月"d"日"`,
+	55: `上午/下午h"时"mm"分"`,
+	56: `上午/下午h"时"mm"分"ss"秒"`,
+	57: `yyyy"年"m"月"`,
+	58: `m"月"d"日"`,
+}
+
+func parseValueMode(value string) (string, error) {
+	value = strings.ToLower(value)
+	switch value {
+	case "raw", "formatted", "both":
+		return value, nil
+	default:
+		return "", fmt.Errorf("--values 只能是 raw, formatted, both")
+	}
+}
+
+func numberFormatCode(file *excelize.File, sheet, cell string) (string, error) {
+	styleIdx, err := file.GetCellStyle(sheet, cell)
+	if err != nil {
+		return "", err
+	}
+	return styleFormatCode(file, styleIdx)
+}
+
+func styleFormatCode(file *excelize.File, styleIdx int) (string, error) {
+	style, err := file.GetStyle(styleIdx)
+	if err != nil {
+		return "", err
+	}
+	if style.CustomNumFmt != nil {
+		return *style.CustomNumFmt, nil
+	}
+	if code, ok := builtInFormatCodes[style.NumFmt]; ok {
+		return code, nil
+	}
+	return fmt.Sprintf("#%d", style.NumFmt), nil
+}
+
+func cellTypeName(file *excelize.File, sheet, cell, raw string) (string, error) {
+	cellType, err := file.GetCellType(sheet, cell)
+	if err != nil {
+		return "", err
+	}
+	switch cellType {
+	case excelize.CellTypeBool:
+		return "bool", nil
+	case excelize.CellTypeDate:
+		return "date", nil
+	case excelize.CellTypeError:
+		return "error", nil
+	case excelize.CellTypeInlineString, excelize.CellTypeSharedString:
+		return "string", nil
+	case excelize.CellTypeFormula:
+		return "string", nil
+	case excelize.CellTypeNumber:
+		return numberTypeName(file, sheet, cell)
+	}
+	if raw == "" {
+		return "empty", nil
+	}
+	return numberTypeName(file, sheet, cell)
+}
+
+func numberTypeName(file *excelize.File, sheet, cell string) (string, error) {
+	code, err := numberFormatCode(file, sheet, cell)
+	if err != nil {
+		return "", err
+	}
+	if hasDate, hasTime := dateFormatParts(code); hasDate || hasTime {
+		return "date", nil
+	}
+	return "number", nil
+}
+
+func describeValue(file *excelize.File, sheet, cell string) (string, error) {
+	raw, err := file.GetCellValue(sheet, cell, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return "", err
+	}
+	if raw == "" {
+		return "", nil
+	}
+	code, err := numberFormatCode(file, sheet, cell)
+	if err != nil {
+		return "", err
+	}
+	kind, err := cellTypeName(file, sheet, cell, raw)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("原值:%s 格式:%s 类型:%s", raw, code, kind), nil
+}
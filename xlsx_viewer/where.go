@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var wherePattern = regexp.MustCompile(`^\s*(.+?)\s*(>=|<=|!=|==|=|>|<)\s*(.*?)\s*$`)
+
+type whereClause struct {
+	raw    string
+	column string
+	op     string
+	value  string
+	col    int
+}
+
+func parseWhere(raw string) (whereClause, error) {
+	match := wherePattern.FindStringSubmatch(raw)
+	if match == nil {
+		return whereClause{}, fmt.Errorf("无效条件: %s (格式: <列> <比较符> <值>)", raw)
+	}
+	op := match[2]
+	if op == "==" {
+		op = "="
+	}
+	return whereClause{raw: raw, column: match[1], op: op, value: match[3]}, nil
+}
+
+func resolveWhere(file *excelize.File, sheet string, clauses []whereClause, headerRows, totalCols int) ([]whereClause, error) {
+	resolved := make([]whereClause, len(clauses))
+	for i, clause := range clauses {
+		col, err := resolveColumn(file, sheet, clause.column, headerRows, totalCols)
+		if err != nil {
+			return nil, err
+		}
+		clause.col = col
+		resolved[i] = clause
+	}
+	return resolved, nil
+}
+
+func filterRowsWhere(file *excelize.File, sheet string, rowIndexes []int, opts options, totalCols int) []int {
+	if len(opts.where) == 0 {
+		return rowIndexes
+	}
+	clauses, err := resolveWhere(file, sheet, opts.where, opts.headerRows, totalCols)
+	if err != nil {
+		exitWithUsageError(err.Error())
+	}
+	return matchRows(file, sheet, rowIndexes, clauses)
+}
+
+func matchRows(file *excelize.File, sheet string, rowIndexes []int, clauses []whereClause) []int {
+	filtered := []int{}
+	for _, row := range rowIndexes {
+		matched := true
+		for _, clause := range clauses {
+			ok, err := clause.match(file, sheet, row)
+			if err != nil {
+				exitWithError(err.Error())
+			}
+			if !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			filtered = append(filtered, row)
+		}
+	}
+	return filtered
+}
+
+// match compares dates as dates and numbers as numbers; only when neither
+// side parses does it fall back to text comparison.
+func (w whereClause) match(file *excelize.File, sheet string, row int) (bool, error) {
+	cell, err := excelize.CoordinatesToCellName(w.col, row)
+	if err != nil {
+		return false, err
+	}
+	if target, ok := parseDateText(w.value); ok {
+		if t, _, ok := cellTime(file, sheet, cell); ok {
+			return compareOrdered(t.Compare(target), w.op), nil
+		}
+		text, err := cellValue(file, sheet, row, w.col)
+		if err != nil {
+			return false, err
+		}
+		if t, ok := parseDateText(strings.TrimSpace(text)); ok {
+			return compareOrdered(t.Compare(target), w.op), nil
+		}
+		return false, nil
+	}
+	text, err := cellValue(file, sheet, row, w.col)
+	if err != nil {
+		return false, err
+	}
+	if target, err := strconv.ParseFloat(w.value, 64); err == nil {
+		raw, err := file.GetCellValue(sheet, cell, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return false, err
+		}
+		if number, err := strconv.ParseFloat(strings.TrimSpace(raw), 64); err == nil {
+			return compareOrdered(compareFloat(number, target), w.op), nil
+		}
+		if w.op != "=" && w.op != "!=" {
+			return false, nil
+		}
+	}
+	return compareOrdered(strings.Compare(text, w.value), w.op), nil
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareOrdered(cmp int, op string) bool {
+	switch op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+var errWhereUnsupported = errors.New("--where 只能用于 --rows, --tail, --search-col, --table")
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"unicode/utf16"
+
+	"github.com/richardlehane/mscfb"
+)
+
+// BIFF8 record types read by the legacy .xls reader.
+const (
+	biffFormula     = 0x0006
+	biffEOF         = 0x000A
+	biffDateMode    = 0x0022
+	biffFilePass    = 0x002F
+	biffContinue    = 0x003C
+	biffColInfo     = 0x007D
+	biffBoundSheet  = 0x0085
+	biffMulRK       = 0x00BD
+	biffXF          = 0x00E0
+	biffMergedCells = 0x00E5
+	biffSST         = 0x00FC
+	biffLabelSST    = 0x00FD
+	biffNumber      = 0x0203
+	biffLabel       = 0x0204
+	biffBoolErr     = 0x0205
+	biffString      = 0x0207
+	biffRow         = 0x0208
+	biffRK          = 0x027E
+	biffFormat      = 0x041E
+	biffBOF         = 0x0809
+)
+
+const biff8Version = 0x0600
+
+var biffErrors = map[byte]string{
+	0x00: "#NULL!",
+	0x07: "#DIV/0!",
+	0x0F: "#VALUE!",
+	0x17: "#REF!",
+	0x1D: "#NAME?",
+	0x24: "#NUM!",
+	0x2A: "#N/A",
+}
+
+var errTruncatedRecord = errors.New("记录长度不足")
+
+type biffRecord struct {
+	kind uint16
+	data []byte
+	// continues holds CONTINUE payloads separately because a string split
+	// across them restarts with a fresh option-flags byte.
+	continues [][]byte
+}
+
+type xlsReader struct{}
+
+type xlsGlobals struct {
+	sheets   []xlsSheetEntry
+	strings  []string
+	formats  map[int]string
+	xfFormat []int
+	date1904 bool
+}
+
+type xlsSheetEntry struct {
+	name   string
+	offset int
+	hidden bool
+}
+
+func (xlsReader) read(path string) (*sourceWorkbook, error) {
+	stream, err := workbookStream(path)
+	if err != nil {
+		return nil, err
+	}
+	records, err := readBIFFRecords(stream, 0)
+	if err != nil {
+		return nil, err
+	}
+	globals, err := parseXLSGlobals(records)
+	if err != nil {
+		return nil, err
+	}
+	book := &sourceWorkbook{
+		kind:     workbookKind{extension: ".xls", description: "旧版工作簿 BIFF8"},
+		date1904: globals.date1904,
+	}
+	for _, entry := range globals.sheets {
+		sheetRecords, err := readBIFFRecords(stream, entry.offset)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", entry.name, err.Error())
+		}
+		sheet, err := parseXLSSheet(entry, sheetRecords, globals)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", entry.name, err.Error())
+		}
+		book.sheets = append(book.sheets, sheet)
+	}
+	return book, nil
+}
+
+func workbookStream(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	doc, err := mscfb.New(file)
+	if err != nil {
+		return nil, fmt.Errorf("复合文档结构损坏: %s", err.Error())
+	}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		switch entry.Name {
+		case "Workbook":
+			return io.ReadAll(entry)
+		case "Book":
+			return nil, errors.New("不支持 Excel 5.0/95 (BIFF5) 格式的 xls 文件")
+		}
+	}
+	return nil, errors.New("xls 文件中没有 Workbook 数据流")
+}
+
+// readBIFFRecords reads one substream starting at offset up to its EOF
+// record, folding CONTINUE records into the record they extend.
+func readBIFFRecords(stream []byte, offset int) ([]biffRecord, error) {
+	records := []biffRecord{}
+	for pos := offset; pos+4 <= len(stream); {
+		kind := binary.LittleEndian.Uint16(stream[pos:])
+		size := int(binary.LittleEndian.Uint16(stream[pos+2:]))
+		pos += 4
+		if pos+size > len(stream) {
+			return nil, errTruncatedRecord
+		}
+		data := stream[pos : pos+size]
+		pos += size
+		if kind == biffContinue && len(records) > 0 {
+			last := &records[len(records)-1]
+			last.continues = append(last.continues, data)
+			continue
+		}
+		records = append(records, biffRecord{kind: kind, data: data})
+		if kind == biffEOF {
+			break
+		}
+	}
+	if len(records) == 0 || records[0].kind != biffBOF {
+		return nil, errors.New("不是有效的 BIFF 数据流")
+	}
+	if len(records[0].data) >= 2 && binary.LittleEndian.Uint16(records[0].data) != biff8Version {
+		return nil, errors.New("只支持 Excel 97-2003 (BIFF8) 格式的 xls 文件")
+	}
+	return records, nil
+}
+
+func parseXLSGlobals(records []biffRecord) (*xlsGlobals, error) {
+	globals := &xlsGlobals{formats: map[int]string{}}
+	for _, record := range records {
+		r := newBIFFReader(record)
+		switch record.kind {
+		case biffFilePass:
+			return nil, errors.New("加密的 xls 文件不受支持, 请在 Excel 中另存为 xlsx 后使用 --password")
+		case biffDateMode:
+			globals.date1904 = r.u16() == 1
+		case biffBoundSheet:
+			offset := int(r.u32())
+			visibility := r.u8()
+			sheetType := r.u8()
+			name := r.shortString()
+			if sheetType == 0 {
+				globals.sheets = append(globals.sheets, xlsSheetEntry{name: name, offset: offset, hidden: visibility != 0})
+			}
+		case biffFormat:
+			id := int(r.u16())
+			globals.formats[id] = r.longString()
+		case biffXF:
+			r.u16()
+			globals.xfFormat = append(globals.xfFormat, int(r.u16()))
+		case biffSST:
+			r.u32()
+			count := int(r.u32())
+			globals.strings = make([]string, 0, count)
+			for i := 0; i < count && !r.failed; i++ {
+				globals.strings = append(globals.strings, r.richString())
+			}
+		}
+		if r.failed {
+			return nil, fmt.Errorf("记录 0x%04X 损坏: %s", record.kind, errTruncatedRecord.Error())
+		}
+	}
+	return globals, nil
+}
+
+func (g *xlsGlobals) formatCode(xf int) string {
+	if xf < 0 || xf >= len(g.xfFormat) {
+		return ""
+	}
+	id := g.xfFormat[xf]
+	if code, ok := g.formats[id]; ok {
+		return code
+	}
+	return builtInFormatCodes[id]
+}
+
+func parseXLSSheet(entry xlsSheetEntry, records []biffRecord, globals *xlsGlobals) (sourceSheet, error) {
+	sheet := sourceSheet{name: entry.name, hidden: entry.hidden}
+	numberCell := func(row, col, xf int, value float64) {
+		sheet.cells = append(sheet.cells, sourceCell{row: row + 1, col: col + 1, kind: sourceNumber, number: value, format: globals.formatCode(xf)})
+	}
+	textCell := func(row, col int, kind sourceCellKind, text string) {
+		sheet.cells = append(sheet.cells, sourceCell{row: row + 1, col: col + 1, kind: kind, text: text})
+	}
+	pendingRow, pendingCol := -1, -1
+	for _, record := range records {
+		r := newBIFFReader(record)
+		switch record.kind {
+		case biffLabelSST:
+			row, col, _ := r.cellHeader()
+			index := int(r.u32())
+			if index < len(globals.strings) {
+				textCell(row, col, sourceText, globals.strings[index])
+			}
+		case biffLabel:
+			row, col, _ := r.cellHeader()
+			textCell(row, col, sourceText, r.longString())
+		case biffNumber:
+			row, col, xf := r.cellHeader()
+			numberCell(row, col, xf, r.f64())
+		case biffRK:
+			row, col, xf := r.cellHeader()
+			numberCell(row, col, xf, decodeRK(r.u32()))
+		case biffMulRK:
+			row := int(r.u16())
+			col := int(r.u16())
+			for r.remaining() > 2 {
+				xf := int(r.u16())
+				numberCell(row, col, xf, decodeRK(r.u32()))
+				col++
+			}
+		case biffBoolErr:
+			row, col, _ := r.cellHeader()
+			value, isError := r.u8(), r.u8()
+			if isError == 1 {
+				textCell(row, col, sourceError, biffErrors[value])
+			} else {
+				textCell(row, col, sourceBool, boolText(value == 1))
+			}
+		case biffFormula:
+			// Only the cached result is read; the parsed-token formula
+			// itself is not decompiled.
+			row, col, xf := r.cellHeader()
+			result := r.bytes(8)
+			if r.failed {
+				break
+			}
+			if result[6] != 0xFF || result[7] != 0xFF {
+				numberCell(row, col, xf, math.Float64frombits(binary.LittleEndian.Uint64(result)))
+				break
+			}
+			switch result[0] {
+			case 0:
+				pendingRow, pendingCol = row, col
+			case 1:
+				textCell(row, col, sourceBool, boolText(result[2] == 1))
+			case 2:
+				textCell(row, col, sourceError, biffErrors[result[2]])
+			}
+		case biffString:
+			if pendingRow >= 0 {
+				textCell(pendingRow, pendingCol, sourceText, r.longString())
+				pendingRow, pendingCol = -1, -1
+			}
+		case biffRow:
+			row := int(r.u16())
+			r.bytes(10)
+			if flags := r.u16(); !r.failed && flags&0x20 != 0 {
+				sheet.hiddenRows = append(sheet.hiddenRows, row+1)
+			}
+		case biffColInfo:
+			first, last := int(r.u16()), int(r.u16())
+			r.bytes(4)
+			if flags := r.u16(); !r.failed && flags&0x01 != 0 {
+				for col := first; col <= last && col < 256; col++ {
+					sheet.hiddenCols = append(sheet.hiddenCols, col+1)
+				}
+			}
+		case biffMergedCells:
+			count := int(r.u16())
+			for i := 0; i < count && !r.failed; i++ {
+				firstRow, lastRow := int(r.u16()), int(r.u16())
+				firstCol, lastCol := int(r.u16()), int(r.u16())
+				if r.failed {
+					break
+				}
+				start := fmt.Sprintf("%s%d", numberToColumn(firstCol+1), firstRow+1)
+				end := fmt.Sprintf("%s%d", numberToColumn(lastCol+1), lastRow+1)
+				sheet.merged = append(sheet.merged, start+":"+end)
+			}
+		}
+		if r.failed {
+			return sheet, fmt.Errorf("记录 0x%04X 损坏: %s", record.kind, errTruncatedRecord.Error())
+		}
+	}
+	return sheet, nil
+}
+
+// decodeRK unpacks the compressed RK number: bit 1 marks a 30-bit integer,
+// otherwise the upper 30 bits of an IEEE double; bit 0 divides by 100.
+func decodeRK(rk uint32) float64 {
+	var value float64
+	if rk&0x02 != 0 {
+		value = float64(int32(rk) >> 2)
+	} else {
+		value = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		value /= 100
+	}
+	return value
+}
+
+// biffReader walks a record's payload and its CONTINUE segments; reads past
+// the end set failed instead of panicking.
+type biffReader struct {
+	segments [][]byte
+	segment  int
+	pos      int
+	failed   bool
+}
+
+func newBIFFReader(record biffRecord) *biffReader {
+	return &biffReader{segments: append([][]byte{record.data}, record.continues...)}
+}
+
+func (r *biffReader) remaining() int {
+	if r.segment >= len(r.segments) {
+		return 0
+	}
+	return len(r.segments[r.segment]) - r.pos
+}
+
+func (r *biffReader) advance() bool {
+	for r.segment < len(r.segments) && r.pos >= len(r.segments[r.segment]) {
+		r.segment++
+		r.pos = 0
+	}
+	return r.segment < len(r.segments)
+}
+
+func (r *biffReader) u8() byte {
+	if r.failed || !r.advance() {
+		r.failed = true
+		return 0
+	}
+	value := r.segments[r.segment][r.pos]
+	r.pos++
+	return value
+}
+
+func (r *biffReader) bytes(n int) []byte {
+	result := make([]byte, n)
+	for i := range result {
+		result[i] = r.u8()
+	}
+	return result
+}
+
+func (r *biffReader) u16() uint16 {
+	return binary.LittleEndian.Uint16(r.bytes(2))
+}
+
+func (r *biffReader) u32() uint32 {
+	return binary.LittleEndian.Uint32(r.bytes(4))
+}
+
+func (r *biffReader) f64() float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(r.bytes(8)))
+}
+
+func (r *biffReader) cellHeader() (int, int, int) {
+	return int(r.u16()), int(r.u16()), int(r.u16())
+}
+
+// chars reads count characters; when the characters run into the next
+// CONTINUE segment, that segment starts with a new high-byte flag.
+func (r *biffReader) chars(count int, highByte bool) string {
+	units := make([]uint16, 0, count)
+	for i := 0; i < count && !r.failed; i++ {
+		if r.remaining() == 0 && r.segment+1 < len(r.segments) {
+			r.segment++
+			r.pos = 0
+			highByte = r.u8()&0x01 != 0
+		}
+		if highByte {
+			units = append(units, r.u16())
+		} else {
+			units = append(units, uint16(r.u8()))
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+func (r *biffReader) shortString() string {
+	count := int(r.u8())
+	return r.chars(count, r.u8()&0x01 != 0)
+}
+
+func (r *biffReader) longString() string {
+	count := int(r.u16())
+	return r.chars(count, r.u8()&0x01 != 0)
+}
+
+func (r *biffReader) richString() string {
+	count := int(r.u16())
+	flags := r.u8()
+	runs, extSize := 0, 0
+	if flags&0x08 != 0 {
+		runs = int(r.u16())
+	}
+	if flags&0x04 != 0 {
+		extSize = int(r.u32())
+	}
+	text := r.chars(count, flags&0x01 != 0)
+	r.skip(runs*4 + extSize)
+	return text
+}
+
+func (r *biffReader) skip(n int) {
+	for i := 0; i < n && !r.failed; i++ {
+		r.u8()
+	}
+}
+
+func boolText(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// isXLSFile recognizes a legacy workbook by its compound file signature; an
+// encrypted xlsx uses the same container and is left to excelize.
+func isXLSFile(path string) bool {
+	return bytes.HasPrefix(readFileHead(path, len(oleSignature)), oleSignature) && !isEncryptedWorkbook(path)
+}
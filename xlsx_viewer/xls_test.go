@@ -0,0 +1,332 @@
+package main
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+func le16(values ...int) []byte {
+	data := make([]byte, 0, len(values)*2)
+	for _, value := range values {
+		data = binary.LittleEndian.AppendUint16(data, uint16(value))
+	}
+	return data
+}
+
+func le32(value uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, value)
+}
+
+func utf16Bytes(value string) []byte {
+	data := []byte{}
+	for _, unit := range utf16.Encode([]rune(value)) {
+		data = binary.LittleEndian.AppendUint16(data, unit)
+	}
+	return data
+}
+
+func join(parts ...[]byte) []byte {
+	data := []byte{}
+	for _, part := range parts {
+		data = append(data, part...)
+	}
+	return data
+}
+
+func TestDecodeRK(t *testing.T) {
+	highBits := func(value float64) uint32 {
+		return uint32(math.Float64bits(value) >> 32)
+	}
+	tests := []struct {
+		name string
+		rk   uint32
+		want float64
+	}{
+		{"integer", 1001<<2 | 0x02, 1001},
+		{"negative integer", 0xFFFFFFE4 | 0x02, -7},
+		{"integer divided by 100", 150<<2 | 0x03, 1.5},
+		{"float", highBits(1.5), 1.5},
+		{"float divided by 100", highBits(12300) | 0x01, 123},
+	}
+	for _, tt := range tests {
+		if got := decodeRK(tt.rk); got != tt.want {
+			t.Errorf("%s: decodeRK(0x%08X) = %v, want %v", tt.name, tt.rk, got, tt.want)
+		}
+	}
+}
+
+func TestSSTStringAcrossContinue(t *testing.T) {
+	// The first string carries a rich-text run and an ext block that must be
+	// skipped; the second starts compressed and switches to UTF-16 in the
+	// CONTINUE record.
+	sst := biffRecord{
+		kind: biffSST,
+		data: join(
+			le32(2), le32(2),
+			le16(2), []byte{0x0C}, le16(1), le32(2), []byte("ID"), le16(0, 0), []byte{0xAA, 0xBB},
+			le16(4), []byte{0x00}, []byte("ab"),
+		),
+		continues: [][]byte{join([]byte{0x01}, utf16Bytes("攻击"))},
+	}
+	globals, err := parseXLSGlobals([]biffRecord{{kind: biffBOF, data: le16(biff8Version, 0x05)}, sst})
+	if err != nil {
+		t.Fatalf("parseXLSGlobals: %v", err)
+	}
+	if want := []string{"ID", "ab攻击"}; !reflect.DeepEqual(globals.strings, want) {
+		t.Fatalf("strings = %q, want %q", globals.strings, want)
+	}
+
+	records := []biffRecord{
+		{kind: biffLabelSST, data: join(le16(1, 2, 15), le32(1))},
+	}
+	sheet, err := parseXLSSheet(xlsSheetEntry{name: "Sheet1"}, records, globals)
+	if err != nil {
+		t.Fatalf("parseXLSSheet: %v", err)
+	}
+	want := []sourceCell{{row: 2, col: 3, kind: sourceText, text: "ab攻击"}}
+	if !reflect.DeepEqual(sheet.cells, want) {
+		t.Fatalf("cells = %+v, want %+v", sheet.cells, want)
+	}
+}
+
+func TestHiddenRowsAndColumns(t *testing.T) {
+	records := []biffRecord{
+		{kind: biffRow, data: join(le16(2, 0, 4, 255, 0, 0), le16(0x0120, 15))},
+		{kind: biffRow, data: join(le16(3, 0, 4, 255, 0, 0), le16(0x0100, 15))},
+		{kind: biffColInfo, data: le16(3, 4, 2000, 15, 0x0001, 0)},
+		{kind: biffColInfo, data: le16(6, 6, 2000, 15, 0x0000, 0)},
+	}
+	sheet, err := parseXLSSheet(xlsSheetEntry{name: "Sheet1"}, records, &xlsGlobals{})
+	if err != nil {
+		t.Fatalf("parseXLSSheet: %v", err)
+	}
+	if want := []int{3}; !reflect.DeepEqual(sheet.hiddenRows, want) {
+		t.Errorf("hiddenRows = %v, want %v", sheet.hiddenRows, want)
+	}
+	if want := []int{4, 5}; !reflect.DeepEqual(sheet.hiddenCols, want) {
+		t.Errorf("hiddenCols = %v, want %v", sheet.hiddenCols, want)
+	}
+}
+
+func TestMulRKCells(t *testing.T) {
+	records := []biffRecord{
+		{kind: biffMulRK, data: join(le16(0, 1), le16(15), le32(5<<2|0x02), le16(15), le32(250<<2|0x03), le16(2))},
+	}
+	sheet, err := parseXLSSheet(xlsSheetEntry{name: "Sheet1"}, records, &xlsGlobals{})
+	if err != nil {
+		t.Fatalf("parseXLSSheet: %v", err)
+	}
+	want := []sourceCell{
+		{row: 1, col: 2, kind: sourceNumber, number: 5},
+		{row: 1, col: 3, kind: sourceNumber, number: 2.5},
+	}
+	if !reflect.DeepEqual(sheet.cells, want) {
+		t.Fatalf("cells = %+v, want %+v", sheet.cells, want)
+	}
+}
+
+func biffBytes(kind uint16, data []byte) []byte {
+	return join(le16(int(kind), len(data)), data)
+}
+
+// xlsWorkbookStream builds the Workbook stream of a one-sheet BIFF8 file:
+// shared and inline strings, RK, MULRK and NUMBER cells with a custom
+// percentage format, a boolean and an error, a hidden row and column.
+func xlsWorkbookStream() []byte {
+	bof := func(kind int) []byte {
+		return biffBytes(biffBOF, le16(biff8Version, kind, 0, 0, 0, 0, 0, 0))
+	}
+	xf := func(format int) []byte {
+		return biffBytes(biffXF, join(le16(0, format), make([]byte, 16)))
+	}
+	sheetName := "Buffs"
+	boundSheet := func(offset int) []byte {
+		return biffBytes(biffBoundSheet, join(le32(uint32(offset)), []byte{0, 0, byte(len(sheetName)), 0}, []byte(sheetName)))
+	}
+	globals := func(offset int) []byte {
+		return join(
+			bof(0x0005),
+			biffBytes(biffFormat, join(le16(164, 4), []byte{0}, []byte("0.0%"))),
+			xf(0),
+			xf(164),
+			boundSheet(offset),
+			biffBytes(biffSST, join(
+				le32(4), le32(4),
+				le16(2), []byte{0}, []byte("ID"),
+				le16(4), []byte{0}, []byte("Name"),
+				le16(4), []byte{0}, []byte("Rate"),
+				le16(4), []byte{1}, utf16Bytes("攻击提升"),
+			)),
+			biffBytes(biffEOF, nil),
+		)
+	}
+	labelSST := func(row, col, index int) []byte {
+		return biffBytes(biffLabelSST, join(le16(row, col, 0), le32(uint32(index))))
+	}
+	number := func(row, col, xf int, value float64) []byte {
+		return biffBytes(biffNumber, join(le16(row, col, xf), binary.LittleEndian.AppendUint64(nil, math.Float64bits(value))))
+	}
+	sheet := join(
+		bof(0x0010),
+		biffBytes(biffRow, join(le16(3, 0, 3, 255, 0, 0), le16(0x0120, 15))),
+		biffBytes(biffColInfo, le16(3, 3, 2000, 15, 0x0001, 0)),
+		labelSST(0, 0, 0),
+		labelSST(0, 1, 1),
+		labelSST(0, 2, 2),
+		labelSST(0, 3, 1),
+		biffBytes(biffRK, join(le16(1, 0, 0), le32(1001<<2|0x02))),
+		labelSST(1, 1, 3),
+		number(1, 2, 1, 0.15),
+		biffBytes(biffMulRK, join(le16(2, 0), le16(0), le32(1002<<2|0x02), le16(2))),
+		biffBytes(biffLabel, join(le16(2, 1, 0), le16(2), []byte{1}, utf16Bytes("防御"))),
+		number(2, 2, 1, 0.2),
+		biffBytes(biffBoolErr, join(le16(3, 0, 0), []byte{1, 0})),
+		biffBytes(biffBoolErr, join(le16(3, 1, 0), []byte{0x2A, 1})),
+		biffBytes(biffEOF, nil),
+	)
+	offset := len(globals(0))
+	return join(globals(offset), sheet)
+}
+
+// compoundFile wraps stream in a version 3 compound file with a single FAT
+// sector and directory sector. The stream is padded to the 4096-byte mini
+// stream cutoff so it is stored in regular sectors.
+func compoundFile(name string, stream []byte) []byte {
+	const sectorSize = 512
+	const (
+		freeSect   = 0xFFFFFFFF
+		endOfChain = 0xFFFFFFFE
+		fatSect    = 0xFFFFFFFD
+		noStream   = 0xFFFFFFFF
+	)
+	padded := append([]byte{}, stream...)
+	for len(padded) < 4096 || len(padded)%sectorSize != 0 {
+		padded = append(padded, 0)
+	}
+	streamSectors := len(padded) / sectorSize
+
+	header := make([]byte, sectorSize)
+	copy(header, oleSignature)
+	copy(header[24:], le16(0x003E, 3, 0xFFFE, 9, 6))
+	copy(header[44:], join(le32(1), le32(1), le32(0), le32(4096), le32(endOfChain), le32(0), le32(endOfChain), le32(0)))
+	for i := 0; i < 109; i++ {
+		copy(header[76+i*4:], le32(freeSect))
+	}
+	copy(header[76:], le32(0))
+
+	fat := make([]byte, 0, sectorSize)
+	fat = append(fat, join(le32(fatSect), le32(endOfChain))...)
+	for i := 0; i < streamSectors; i++ {
+		next := uint32(3 + i)
+		if i == streamSectors-1 {
+			next = endOfChain
+		}
+		fat = append(fat, le32(next)...)
+	}
+	for len(fat) < sectorSize {
+		fat = append(fat, le32(freeSect)...)
+	}
+
+	entry := func(name string, kind byte, child, start uint32, size int) []byte {
+		data := make([]byte, 128)
+		units := utf16.Encode([]rune(name))
+		for i, unit := range units {
+			binary.LittleEndian.PutUint16(data[i*2:], unit)
+		}
+		binary.LittleEndian.PutUint16(data[64:], uint16(len(units)*2+2))
+		data[66], data[67] = kind, 1
+		copy(data[68:], join(le32(noStream), le32(noStream), le32(child)))
+		copy(data[116:], join(le32(start), le32(uint32(size))))
+		return data
+	}
+	directory := join(
+		entry("Root Entry", 5, 1, endOfChain, 0),
+		entry(name, 2, noStream, 2, len(padded)),
+		entry("", 0, noStream, 0, 0),
+		entry("", 0, noStream, 0, 0),
+	)
+	return join(header, fat, directory, padded)
+}
+
+// captureOutput runs fn with stdout redirected and the output budget and
+// display options set from opts, and returns what it printed.
+func captureOutput(t *testing.T, opts options, fn func()) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() {
+		os.Stdout = stdout
+	}()
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		done <- data
+	}()
+	configureOutput(opts)
+	configureDisplay(opts)
+	fn()
+	_ = writer.Close()
+	return string(<-done)
+}
+
+// runOperation writes data to a file named name and runs the command line
+// args against it the way main does, returning the printed output.
+func runOperation(t *testing.T, name string, data []byte, args ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	opts, err := parseArgs(append([]string{"--path", path}, args...))
+	if err != nil {
+		t.Fatalf("parseArgs: %v", err)
+	}
+	file, sheet, err := openWorkbook(path, opts)
+	if err != nil {
+		t.Fatalf("openWorkbook: %v", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	rows, cols, err := sheetSize(file, sheet)
+	if err != nil {
+		t.Fatalf("sheetSize: %v", err)
+	}
+	return captureOutput(t, opts, func() {
+		switch opts.op {
+		case opSize:
+			handleSize(file, sheet, rows, cols)
+		case opRows:
+			handleRows(file, sheet, rows, cols, opts)
+		default:
+			t.Fatalf("unexpected operation in %v", args)
+		}
+	})
+}
+
+func TestXLSWorkbookOperations(t *testing.T) {
+	data := compoundFile("Workbook", xlsWorkbookStream())
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--size"}, "Rows:4,Cols:4\nHiddenRows:4\nHiddenCols:D\n"},
+		{[]string{"--rows", "1", "4"}, ",A,B,C,D\n1,ID,Name,Rate,Name\n2,1001,攻击提升,15.0%,\n3,1002,防御,20.0%,\n4,TRUE,#N/A,,\n"},
+		{[]string{"--rows", "1", "4", "--skip-hidden", "--values", "raw"}, ",A,B,C\n1,ID,Name,Rate\n2,1001,攻击提升,0.15\n3,1002,防御,0.2\n"},
+	}
+	for _, tt := range tests {
+		if got := runOperation(t, "buffs.xls", data, tt.args...); got != tt.want {
+			t.Errorf("%v:\ngot:\n%s\nwant:\n%s", tt.args, got, tt.want)
+		}
+	}
+}